@@ -0,0 +1,112 @@
+package gorm
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// aggregate query the aggregate function of the column into dest, dest is set to zero value for NULL, e.g: SUM of no rows,
+// when the query is grouped, dest could be a slice to receive the result of each group
+func (scope *Scope) aggregate(function string, column string, dest interface{}) *Scope {
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.IsNil() {
+		scope.Err(fmt.Errorf("%v destination should be a pointer, not %T", function, dest))
+		return scope
+	}
+
+	var (
+		results  = destValue.Elem()
+		isSlice  = results.Kind() == reflect.Slice && results.Type().Elem().Kind() != reflect.Uint8
+		elemType = results.Type()
+	)
+
+	if isSlice {
+		elemType = elemType.Elem()
+		results.Set(reflect.MakeSlice(results.Type(), 0, 0))
+	} else {
+		results.Set(reflect.Zero(elemType))
+	}
+
+	if len(scope.Search.group) == 0 {
+		scope.Search.ignoreOrderQuery = true
+	}
+
+	expr := fmt.Sprintf("%v(%v)", function, scope.quoteIfPossible(column))
+	if len(scope.Search.setOperations) > 0 {
+		scope.InstanceSet("gorm:set_operation_select", expr)
+	} else {
+		scope.Search.Select(expr)
+	}
+
+	rows, err := scope.rows()
+	if scope.Err(err) != nil {
+		return scope
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var elem reflect.Value
+		if elemType == timeType || elemType == reflect.PtrTo(timeType) {
+			// sqlite returns aggregates of time columns as text
+			var value interface{}
+			if scope.Err(rows.Scan(&value)) != nil {
+				return scope
+			}
+
+			t, err := aggregateTime(value)
+			if scope.Err(err) != nil {
+				return scope
+			}
+
+			elem = reflect.ValueOf(t)
+			if elemType != timeType {
+				elem = reflect.Zero(elemType)
+				if value != nil {
+					elem = reflect.ValueOf(&t)
+				}
+			}
+		} else {
+			// scan into a pointer, so NULL is accepted
+			value := reflect.New(reflect.PtrTo(elemType))
+			if scope.Err(rows.Scan(value.Interface())) != nil {
+				return scope
+			}
+
+			elem = reflect.Zero(elemType)
+			if !value.Elem().IsNil() {
+				elem = value.Elem().Elem()
+			}
+		}
+
+		if !isSlice {
+			results.Set(elem)
+			break
+		}
+		results.Set(reflect.Append(results, elem))
+	}
+
+	if err := rows.Err(); err != nil {
+		scope.Err(err)
+	}
+	return scope
+}
+
+// aggregateTime convert the aggregate of a time column to time.Time, NULL is converted to zero time
+func aggregateTime(value interface{}) (time.Time, error) {
+	switch value := value.(type) {
+	case nil:
+		return time.Time{}, nil
+	case time.Time:
+		return value, nil
+	case []byte:
+		return aggregateTime(string(value))
+	case string:
+		for _, layout := range scanMapTimeLayouts {
+			if t, err := time.Parse(layout, value); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("can't convert %v to time.Time", value)
+}
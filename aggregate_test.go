@@ -0,0 +1,84 @@
+package gorm_test
+
+import (
+	"testing"
+	"time"
+)
+
+type AggregateOrder struct {
+	Id        int64
+	UserId    int64
+	Amount    int64
+	PaidAt    time.Time
+	DeletedAt *time.Time
+}
+
+func TestAggregates(t *testing.T) {
+	DB.DropTableIfExists(&AggregateOrder{})
+	if err := DB.AutoMigrate(&AggregateOrder{}).Error(); err != nil {
+		t.Fatalf("Failed to migrate, got error: %v", err)
+	}
+
+	paidAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	orders := []AggregateOrder{{UserId: 1, Amount: 10, PaidAt: paidAt}, {UserId: 1, Amount: 20, PaidAt: paidAt.Add(time.Hour)},
+		{UserId: 2, Amount: 30, PaidAt: paidAt.Add(2 * time.Hour)}, {UserId: 2, Amount: 100, PaidAt: paidAt.Add(3 * time.Hour)}}
+	for i := range orders {
+		DB.Save(&orders[i])
+	}
+	DB.Delete(&orders[3])
+
+	var sum int64
+	if err := DB.Model(&AggregateOrder{}).Sum("amount", &sum).Error(); err != nil {
+		t.Fatalf("No error should happen when sum, but got %v", err)
+	}
+	if sum != 60 {
+		t.Errorf("Sum should exclude soft deleted records, but got %v", sum)
+	}
+
+	var average float64
+	DB.Model(&AggregateOrder{}).Where("user_id = ?", 1).Avg("amount", &average)
+	if average != 15 {
+		t.Errorf("Avg should respect conditions, but got %v", average)
+	}
+
+	var min, max int64
+	DB.Model(&AggregateOrder{}).Min("amount", &min).Max("amount", &max)
+	if min != 10 || max != 30 {
+		t.Errorf("Should get min and max, but got %v, %v", min, max)
+	}
+
+	var firstPaid, lastPaid time.Time
+	if err := DB.Model(&AggregateOrder{}).Min("paid_at", &firstPaid).Max("paid_at", &lastPaid).Error(); err != nil {
+		t.Fatalf("No error should happen when get min of time column, but got %v", err)
+	}
+	if !firstPaid.Equal(paidAt) || !lastPaid.Equal(paidAt.Add(2*time.Hour)) {
+		t.Errorf("Should get min and max of time column, but got %v, %v", firstPaid, lastPaid)
+	}
+
+	var lastPaidPtr, noPaidPtr *time.Time
+	DB.Model(&AggregateOrder{}).Max("paid_at", &lastPaidPtr).Where("user_id = ?", 3).Max("paid_at", &noPaidPtr)
+	if lastPaidPtr == nil || !lastPaidPtr.Equal(paidAt.Add(2*time.Hour)) || noPaidPtr != nil {
+		t.Errorf("Should get max of time column into pointer, nil if no rows, but got %v, %v", lastPaidPtr, noPaidPtr)
+	}
+
+	sum = 100
+	if err := DB.Model(&AggregateOrder{}).Where("user_id = ?", 3).Sum("amount", &sum).Error(); err != nil || sum != 0 {
+		t.Errorf("Sum of no rows should be zero without error, but got %v, %v", sum, err)
+	}
+
+	var maxAmount *int64
+	DB.Model(&AggregateOrder{}).Where("user_id = ?", 3).Max("amount", &maxAmount)
+	if maxAmount != nil {
+		t.Errorf("Max of no rows should be nil for pointer destination, but got %v", *maxAmount)
+	}
+
+	var sums []int64
+	DB.Model(&AggregateOrder{}).Group("user_id").Order("user_id").Sum("amount", &sums)
+	if len(sums) != 2 || sums[0] != 30 || sums[1] != 30 {
+		t.Errorf("Should sum each group, but got %v", sums)
+	}
+
+	if err := DB.Model(&AggregateOrder{}).Sum("amount", sum).Error(); err == nil {
+		t.Errorf("Should return error when destination isn't a pointer")
+	}
+}
@@ -0,0 +1,141 @@
+package gorm
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// RowIterator decodes query results one record at a time, returned by `Iterate`
+//     iter, err := db.Where("active = ?", true).Iterate(&user)
+//     defer iter.Close()
+//     for iter.Next() {
+//       // user contains current record
+//     }
+//     err = iter.Err()
+type RowIterator struct {
+	scope   *Scope
+	rows    *sql.Rows
+	columns []string
+	err     error
+}
+
+// Next decode next record into the destination, return false when there are no more records or an error happened
+func (it *RowIterator) Next() bool {
+	if it.rows == nil || it.err != nil {
+		return false
+	}
+
+	if !it.rows.Next() {
+		it.err = it.rows.Err()
+		it.Close()
+		return false
+	}
+
+	// reset destination and its fields, so values of the previous record won't leak into current one
+	value := it.scope.IndirectValue()
+	value.Set(reflect.Zero(value.Type()))
+	it.scope.fields = nil
+
+	it.scope.scan(it.rows, it.columns, it.scope.Fields())
+	if it.err = it.scope.db.Error(); it.err != nil {
+		it.Close()
+		return false
+	}
+
+	it.scope.CallMethod("AfterFind")
+	it.err = it.scope.db.Error()
+	return it.err == nil
+}
+
+// Err return the error happened during iteration
+func (it *RowIterator) Err() error {
+	return it.err
+}
+
+// Close close underlying rows, it is called automatically after iterated all records
+func (it *RowIterator) Close() error {
+	if it.rows == nil {
+		return nil
+	}
+	return it.rows.Close()
+}
+
+func (scope *Scope) iterate() (*RowIterator, error) {
+	if scope.IndirectValue().Kind() != reflect.Struct || reflect.ValueOf(scope.Value).Kind() != reflect.Ptr {
+		return nil, scope.Err(errors.New("unsupported destination, should be a pointer to struct"))
+	}
+
+	rows, err := scope.rows()
+	if err != nil {
+		return nil, scope.Err(err)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		rows.Close()
+		return nil, scope.Err(err)
+	}
+
+	return &RowIterator{scope: scope, rows: rows, columns: columns}, nil
+}
+
+func (scope *Scope) findInBatches(batchSize int, fc func(tx Repository, batch int) error) *Scope {
+	results := indirect(reflect.ValueOf(scope.Value))
+	if results.Kind() != reflect.Slice {
+		scope.Err(fmt.Errorf("results should be a slice, not %s", results.Kind()))
+		return scope
+	}
+
+	if batchSize <= 0 {
+		scope.Err(fmt.Errorf("invalid batch size %v", batchSize))
+		return scope
+	}
+
+	primaryField := scope.PrimaryField()
+	if primaryField == nil {
+		scope.Err(errors.New("find in batches requires a primary key"))
+		return scope
+	}
+
+	var (
+		column       = fmt.Sprintf("%v.%v", scope.QuotedTableName(), scope.Quote(primaryField.DBName))
+		lastKey      interface{}
+		rowsAffected int64
+	)
+
+	for batch := 1; ; batch++ {
+		tx := scope.db.Order(column+" ASC", true).Limit(batchSize)
+		if batch > 1 {
+			tx = tx.Where(column+" > ?", lastKey)
+		}
+
+		if tx = tx.Find(scope.Value); tx.Error() != nil {
+			scope.Err(tx.Error())
+			break
+		}
+
+		count := results.Len()
+		if count == 0 {
+			break
+		}
+		rowsAffected += int64(count)
+
+		// take the last key before calling fc, as it might change results
+		last, _ := scope.New(indirect(results.Index(count - 1)).Addr().Interface()).FieldByName(primaryField.Name)
+		lastKey = last.Field.Interface()
+
+		if err := fc(tx, batch); err != nil {
+			scope.Err(err)
+			break
+		}
+
+		if count < batchSize {
+			break
+		}
+	}
+
+	scope.db.SetRowsAffected(rowsAffected)
+	return scope
+}
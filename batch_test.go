@@ -0,0 +1,95 @@
+package gorm_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zhinanxing/gorm/v3"
+)
+
+func TestFindInBatches(t *testing.T) {
+	for i := 0; i < 7; i++ {
+		DB.Save(&User{Name: "BatchUser", Age: int64(i)})
+	}
+
+	var (
+		users   []User
+		batches []int
+		ids     = map[int64]bool{}
+	)
+
+	result := DB.Where("name = ?", "BatchUser").FindInBatches(&users, 3, func(tx gorm.Repository, batch int) error {
+		if tx.RowsAffected() != int64(len(users)) {
+			t.Errorf("RowsAffected of the batch should be %v, but got %v", len(users), tx.RowsAffected())
+		}
+
+		batches = append(batches, len(users))
+		for _, user := range users {
+			if ids[user.Id] {
+				t.Errorf("User %v should only be found once", user.Id)
+			}
+			ids[user.Id] = true
+		}
+		return nil
+	})
+
+	if result.Error() != nil {
+		t.Errorf("No error should happen when find in batches, but got %v", result.Error())
+	}
+
+	if len(batches) != 3 || batches[0] != 3 || batches[1] != 3 || batches[2] != 1 {
+		t.Errorf("Should find users in 3 batches, but got %v", batches)
+	}
+
+	if len(ids) != 7 || result.RowsAffected() != 7 {
+		t.Errorf("Should find all 7 users, but got %v, rows affected %v", len(ids), result.RowsAffected())
+	}
+
+	var count int
+	stopErr := errors.New("stop")
+	result = DB.Where("name = ?", "BatchUser").FindInBatches(&users, 2, func(tx gorm.Repository, batch int) error {
+		count++
+		return stopErr
+	})
+
+	if count != 1 || result.Error() != stopErr {
+		t.Errorf("Should stop when fc returns error, but called %v times, got error %v", count, result.Error())
+	}
+}
+
+func TestIterate(t *testing.T) {
+	DB.Save(&User{Name: "IterateUser", Age: 1, UserNum: Num(10)})
+	DB.Save(&User{Name: "IterateUser", Age: 2})
+
+	var (
+		user  User
+		users []User
+	)
+
+	iter, err := DB.Where("name = ?", "IterateUser").Order("age").Iterate(&user)
+	if err != nil {
+		t.Fatalf("No error should happen when iterate, but got %v", err)
+	}
+	defer iter.Close()
+
+	for iter.Next() {
+		users = append(users, user)
+	}
+
+	if err := iter.Err(); err != nil {
+		t.Errorf("No error should happen during iteration, but got %v", err)
+	}
+
+	if len(users) != 2 || users[0].Age != 1 || users[1].Age != 2 {
+		t.Fatalf("Should iterate all users in order, but got %v", users)
+	}
+
+	if users[0].UserNum != 10 || users[1].UserNum != 0 {
+		t.Errorf("Values of previous record should not leak into next one, but got %v, %v", users[0].UserNum, users[1].UserNum)
+	}
+
+	var emails []Email
+	if _, err := DB.Iterate(&emails); err == nil {
+		t.Errorf("Should return error when iterate with a slice")
+	}
+}
@@ -51,6 +51,7 @@ func updateTimeStampForCreateCallback(scope *Scope) {
 func createCallback(scope *Scope) {
 	if !scope.HasError() {
 		defer scope.trace(NowFunc())
+		scope.InstanceSet("gorm:operation", "create")
 
 		var (
 			columns, placeholders        []string
@@ -115,6 +116,7 @@ func createCallback(scope *Scope) {
 		}
 
 		// execute create sql
+		scope.addComment()
 		if lastInsertIDReturningSuffix == "" || primaryField == nil {
 			if result, err := scope.SQLDB().Exec(scope.SQL, scope.SQLVars...); scope.Err(err) == nil {
 				// set rows affected count
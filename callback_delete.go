@@ -28,6 +28,7 @@ func beforeDeleteCallback(scope *Scope) {
 // deleteCallback used to delete data from database or set deleted_at to current time (when using with soft delete)
 func deleteCallback(scope *Scope) {
 	if !scope.HasError() {
+		scope.InstanceSet("gorm:operation", "delete")
 		var extraOption string
 		if str, ok := scope.Get("gorm:delete_option"); ok {
 			extraOption = fmt.Sprint(str)
@@ -1,6 +1,7 @@
 package gorm
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"reflect"
@@ -20,9 +21,13 @@ func queryCallback(scope *Scope) {
 	}
 
 	defer scope.trace(NowFunc())
+	scope.InstanceSet("gorm:operation", "query")
+	// resolve associations of `JoinsPreload` once, as they are used by both select and joins
+	scope.InstanceSet("gorm:joins_preload", scope.joinsPreloadFields())
 
 	var (
 		isSlice, isPtr bool
+		isMap          bool
 		resultType     reflect.Type
 		results        = scope.IndirectValue()
 	)
@@ -46,8 +51,11 @@ func queryCallback(scope *Scope) {
 			isPtr = true
 			resultType = resultType.Elem()
 		}
+		isMap = isScanMapType(resultType)
+	} else if kind == reflect.Map && isScanMapType(results.Type()) {
+		isMap = true
 	} else if kind != reflect.Struct {
-		scope.Err(errors.New("unsupported destination, should be slice or struct"))
+		scope.Err(errors.New("unsupported destination, should be slice, struct or map"))
 		return
 	}
 
@@ -58,11 +66,17 @@ func queryCallback(scope *Scope) {
 		if str, ok := scope.Get("gorm:query_option"); ok {
 			scope.SQL += addExtraSpaceIfExist(fmt.Sprint(str))
 		}
+		scope.addComment()
 
 		if rows, err := scope.SQLDB().Query(scope.SQL, scope.SQLVars...); scope.Err(err) == nil {
 			defer rows.Close()
 
 			columns, _ := rows.Columns()
+			var columnTypes []*sql.ColumnType
+			if isMap {
+				columnTypes, _ = rows.ColumnTypes()
+			}
+
 			for rows.Next() {
 				scope.db.SetRowsAffected(scope.db.RowsAffected() + 1)
 
@@ -71,7 +85,11 @@ func queryCallback(scope *Scope) {
 					elem = reflect.New(resultType).Elem()
 				}
 
-				scope.scan(rows, columns, scope.New(elem.Addr().Interface()).Fields())
+				if isMap {
+					scope.scanMap(rows, columns, columnTypes, elem)
+				} else {
+					scope.scan(rows, columns, scope.New(elem.Addr().Interface()).Fields())
+				}
 
 				if isSlice {
 					if isPtr {
@@ -18,6 +18,10 @@ func preloadCallback(scope *Scope) {
 		autoPreload(scope)
 	}
 
+	if scope.Search.preloadCount != nil && !scope.HasError() {
+		scope.preloadCounts()
+	}
+
 	if scope.Search.preload == nil || scope.HasError() {
 		return
 	}
@@ -124,6 +128,50 @@ func (scope *Scope) generatePreloadDBWithConditions(conditions []interface{}) (R
 	return preloadDB, preloadConditions
 }
 
+// preloadSelectKeys add columns required to match preloaded associations with their parents to selected columns if they are missing
+//     db.Preload("Emails", func(db gorm.Repository) gorm.Repository { return db.Select("id, email") }).Find(&users)
+//     // SELECT id, email, "user_id" FROM "emails" WHERE ("user_id" IN (1,2))
+func (scope *Scope) preloadSelectKeys(preloadDB Repository, columns ...string) Repository {
+	search := preloadDB.Search()
+	if search == nil || len(search.selects) == 0 {
+		return preloadDB
+	}
+
+	var selected []string
+	switch query := search.selects["query"].(type) {
+	case string:
+		selected = strings.Split(query, ",")
+	case []string:
+		selected = query
+	default:
+		return preloadDB
+	}
+
+	selectedColumns := map[string]bool{}
+	for _, column := range selected {
+		if column = strings.TrimSpace(column); column == "*" || strings.HasSuffix(column, ".*") {
+			return preloadDB
+		}
+		selectedColumns[normalizeColumnName(column)] = true
+	}
+
+	for _, column := range columns {
+		if !selectedColumns[normalizeColumnName(column)] {
+			if !strings.Contains(column, ".") {
+				column = scope.Quote(column)
+			}
+			selected = append(selected, column)
+			selectedColumns[normalizeColumnName(column)] = true
+		}
+	}
+
+	args, _ := search.selects["args"].([]interface{})
+	if _, ok := search.selects["query"].([]string); ok {
+		return preloadDB.Select(selected, args...)
+	}
+	return preloadDB.Select(strings.Join(selected, ","), args...)
+}
+
 // handleHasOnePreload used to preload has one associations
 func (scope *Scope) handleHasOnePreload(field *Field, conditions []interface{}) {
 	relation := field.Relationship
@@ -136,6 +184,7 @@ func (scope *Scope) handleHasOnePreload(field *Field, conditions []interface{})
 
 	// preload conditions
 	preloadDB, preloadConditions := scope.generatePreloadDBWithConditions(conditions)
+	preloadDB = scope.preloadSelectKeys(preloadDB, relation.ForeignDBNames...)
 
 	// find relations
 	query := fmt.Sprintf("%v IN (%v)", toQueryCondition(scope, relation.ForeignDBNames), toQueryMarks(primaryKeys))
@@ -185,6 +234,7 @@ func (scope *Scope) handleHasManyPreload(field *Field, conditions []interface{})
 
 	// preload conditions
 	preloadDB, preloadConditions := scope.generatePreloadDBWithConditions(conditions)
+	preloadDB = scope.preloadSelectKeys(preloadDB, relation.ForeignDBNames...)
 
 	// find relations
 	query := fmt.Sprintf("%v IN (%v)", toQueryCondition(scope, relation.ForeignDBNames), toQueryMarks(primaryKeys))
@@ -195,7 +245,11 @@ func (scope *Scope) handleHasManyPreload(field *Field, conditions []interface{})
 	}
 
 	results := makeSlice(field.Struct.Type)
-	scope.Err(preloadDB.Where(query, values...).Find(results, preloadConditions...).Error())
+	if _, _, ok := preloadLimit(preloadDB); ok {
+		scope.Err(scope.preloadWithLimit(preloadDB, field, primaryKeys, preloadConditions, results))
+	} else {
+		scope.Err(preloadDB.Where(query, values...).Find(results, preloadConditions...).Error())
+	}
 
 	// assign find results
 	var (
@@ -232,6 +286,7 @@ func (scope *Scope) handleBelongsToPreload(field *Field, conditions []interface{
 
 	// preload conditions
 	preloadDB, preloadConditions := scope.generatePreloadDBWithConditions(conditions)
+	preloadDB = scope.preloadSelectKeys(preloadDB, relation.AssociationForeignDBNames...)
 
 	// get relations's primary keys
 	primaryKeys := scope.getColumnAsArray(relation.ForeignFieldNames, scope.Value)
@@ -296,6 +351,12 @@ func (scope *Scope) handleManyToManyPreload(field *Field, conditions []interface
 
 	if len(preloadDB.Search().selects) == 0 {
 		preloadDB = preloadDB.Select("*")
+	} else {
+		var joinTableKeys []string
+		for _, sourceKey := range sourceKeys {
+			joinTableKeys = append(joinTableKeys, fmt.Sprintf("%v.%v", scope.Quote(joinTableHandler.Table(preloadDB)), scope.Quote(sourceKey)))
+		}
+		preloadDB = scope.preloadSelectKeys(preloadDB, joinTableKeys...)
 	}
 
 	preloadDB = joinTableHandler.JoinWith(joinTableHandler, preloadDB, scope.Value)
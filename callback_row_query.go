@@ -19,7 +19,9 @@ type RowsQueryResult struct {
 // queryCallback used to query data from database
 func rowQueryCallback(scope *Scope) {
 	if result, ok := scope.InstanceGet("row_query_result"); ok {
+		scope.InstanceSet("gorm:operation", "query")
 		scope.prepareQuerySQL()
+		scope.addComment()
 
 		if rowResult, ok := result.(*RowQueryResult); ok {
 			rowResult.Row = scope.SQLDB().QueryRow(scope.SQL, scope.SQLVars...)
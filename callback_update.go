@@ -3,6 +3,7 @@ package gorm
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"sort"
 	"strings"
 )
@@ -23,6 +24,15 @@ func init() {
 // assignUpdatingAttributesCallback assign updating attributes to model
 func assignUpdatingAttributesCallback(scope *Scope) {
 	if attrs, ok := scope.InstanceGet("gorm:update_interface"); ok {
+		// fields named with `UpdatesFields` are updated even if blank
+		if names, ok := scope.InstanceGet("gorm:update_fields"); ok && reflect.Indirect(reflect.ValueOf(attrs)).Kind() == reflect.Struct {
+			var err error
+			if attrs, err = updateAttrsWithFields(attrs, names.([]interface{}), scope.IndirectValue().Kind() == reflect.Struct); scope.Err(err) != nil {
+				scope.SkipLeft()
+				return
+			}
+		}
+
 		if updateMaps, hasUpdate := scope.updatedAttrsWithValues(attrs); hasUpdate {
 			scope.InstanceSet("gorm:update_attrs", updateMaps)
 		} else {
@@ -57,6 +67,7 @@ func updateTimeStampForUpdateCallback(scope *Scope) {
 // updateCallback the callback used to update data to database
 func updateCallback(scope *Scope) {
 	if !scope.HasError() {
+		scope.InstanceSet("gorm:operation", "update")
 		var sqls []string
 
 		if updateAttrs, ok := scope.InstanceGet("gorm:update_attrs"); ok {
@@ -0,0 +1,346 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/jinzhu/inflection"
+	"github.com/zhinanxing/gorm/v3"
+)
+
+var (
+	commonInitialisms = map[string]bool{"API": true, "ASCII": true, "CPU": true, "CSS": true, "DNS": true, "EOF": true, "GUID": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true, "JSON": true, "LHS": true, "QPS": true, "RAM": true, "RHS": true, "RPC": true, "SLA": true, "SMTP": true, "SSH": true, "TLS": true, "TTL": true, "UID": true, "UI": true, "UUID": true, "URI": true, "URL": true, "UTF8": true, "VM": true, "XML": true, "XSRF": true, "XSS": true}
+	sizeRegexp        = regexp.MustCompile(`(?i)^(?:n?var)?char(?:acter varying|acter)?\s*\((\d+)\)$`)
+	// integerRegexp integer types matched as a word, e.g: `int(11)`, `bigint unsigned`, `int8`, `serial`, but not `interval`, `point`
+	integerRegexp = regexp.MustCompile(`\b(?:tiny|small|medium|big)?(?:integer|int[248]?|serial[48]?)\b`)
+)
+
+// structDef a Go struct generated for a table
+type structDef struct {
+	Name       string
+	Table      string
+	TableName  bool // generate `TableName` method as the table name isn't the default one
+	EmbedModel bool
+	Fields     []fieldDef
+
+	table      *Table
+	primaryKey string // field name of the primary key
+}
+
+// fieldDef a field of the struct, for columns or associations
+type fieldDef struct {
+	Name string
+	Type string
+	Tag  string
+}
+
+// toCamel convert names like `user_id` to `UserID`
+func toCamel(name string) string {
+	var result string
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' || r == ' ' }) {
+		if upper := strings.ToUpper(part); commonInitialisms[upper] {
+			result += upper
+		} else {
+			result += strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return result
+}
+
+// modelColumns columns of `gorm.Model`, tables having all of them embed it
+func modelColumns() map[string]bool {
+	columns := map[string]bool{}
+	modelType := reflect.TypeOf(gorm.Model{})
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		if !strings.Contains(field.Tag.Get("gorm"), "-") {
+			columns[gorm.ToDBName(field.Name)] = true
+		}
+	}
+	return columns
+}
+
+// goType map the database type to Go type
+func goType(column *Column) (typ string, usesTime bool) {
+	databaseType := strings.ToLower(column.Type)
+	switch {
+	case databaseType == "tinyint(1)" || databaseType == "bit" || strings.Contains(databaseType, "bool"):
+		typ = "bool"
+	case integerRegexp.MatchString(databaseType):
+		typ = "int64"
+	case strings.Contains(databaseType, "char") || strings.Contains(databaseType, "text") || strings.Contains(databaseType, "clob"):
+		typ = "string"
+	case strings.Contains(databaseType, "real") || strings.Contains(databaseType, "floa") || strings.Contains(databaseType, "doub") ||
+		strings.Contains(databaseType, "numeric") || strings.Contains(databaseType, "decimal") || strings.Contains(databaseType, "money"):
+		typ = "float64"
+	case strings.Contains(databaseType, "date") || strings.Contains(databaseType, "time"):
+		typ, usesTime = "time.Time", true
+	case strings.Contains(databaseType, "blob") || strings.Contains(databaseType, "binary") || strings.Contains(databaseType, "bytea"):
+		return "[]byte", false
+	default:
+		typ = "string"
+	}
+
+	if column.Nullable {
+		typ = "*" + typ
+	}
+	return typ, usesTime
+}
+
+// columnTag build the `gorm` tag of the column
+func columnTag(table *Table, column *Column, fieldName string, primaryKeys int) string {
+	var settings []string
+	if gorm.ToDBName(fieldName) != column.Name {
+		settings = append(settings, "column:"+column.Name)
+	}
+
+	if column.PrimaryKey && (primaryKeys > 1 || column.Name != "id") {
+		settings = append(settings, "primary_key")
+	}
+
+	if matches := sizeRegexp.FindStringSubmatch(column.Type); len(matches) > 1 {
+		settings = append(settings, "size:"+matches[1])
+	}
+
+	if !column.Nullable && !column.PrimaryKey {
+		settings = append(settings, "not null")
+	}
+
+	if column.Default != nil && !strings.ContainsAny(*column.Default, ";\"`") {
+		settings = append(settings, "default:"+*column.Default)
+	}
+
+	for _, index := range table.Indexes {
+		for _, name := range index.Columns {
+			if name != column.Name {
+				continue
+			}
+
+			switch {
+			case index.Implicit && len(index.Columns) == 1:
+				settings = append(settings, "unique")
+			case index.Unique:
+				settings = append(settings, "unique_index:"+index.Name)
+			default:
+				settings = append(settings, "index:"+index.Name)
+			}
+		}
+	}
+
+	if len(settings) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("`gorm:\"%v\"`", strings.Join(settings, ";"))
+}
+
+// buildStructs build structs of tables with columns, associations are added by `buildAssociations`
+func buildStructs(tables []*Table) (structs []*structDef, usesTime bool) {
+	conventional := modelColumns()
+	for _, table := range tables {
+		def := &structDef{Name: toCamel(inflection.Singular(table.Name)), Table: table.Name, table: table}
+		def.TableName = inflection.Plural(gorm.ToDBName(def.Name)) != table.Name
+
+		var (
+			found       int
+			primaryKeys int
+		)
+		for _, column := range table.Columns {
+			if conventional[column.Name] {
+				found++
+			}
+			if column.PrimaryKey {
+				primaryKeys++
+			}
+		}
+
+		idColumn := false
+		for _, column := range table.Columns {
+			idColumn = idColumn || column.Name == "id" && column.PrimaryKey
+		}
+		def.EmbedModel = found == len(conventional) && idColumn && primaryKeys == 1
+
+		for _, column := range table.Columns {
+			if def.EmbedModel && conventional[column.Name] {
+				if column.Name == "id" {
+					def.primaryKey = "ID"
+				}
+				continue
+			}
+
+			name := toCamel(column.Name)
+			typ, isTime := goType(column)
+			usesTime = usesTime || isTime
+			def.Fields = append(def.Fields, fieldDef{Name: name, Type: typ, Tag: columnTag(table, column, name, primaryKeys)})
+
+			if column.PrimaryKey && def.primaryKey == "" {
+				def.primaryKey = name
+			}
+		}
+		structs = append(structs, def)
+	}
+	return structs, usesTime
+}
+
+func (def *structDef) hasField(name string) bool {
+	for _, field := range def.Fields {
+		if field.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueFieldName return the name, or the name with suffix `Ref`, `Ref2`, `Ref3`... if the struct has the field already
+func (def *structDef) uniqueFieldName(name string) string {
+	if !def.hasField(name) {
+		return name
+	}
+
+	unique := name + "Ref"
+	for i := 2; def.hasField(unique); i++ {
+		unique = fmt.Sprintf("%vRef%d", name, i)
+	}
+	return unique
+}
+
+func (def *structDef) fieldOfColumn(column string) string {
+	if def.EmbedModel && column == "id" {
+		return "ID"
+	}
+	return toCamel(column)
+}
+
+// buildAssociations add belongs_to fields for foreign keys, and has_many fields to referenced structs
+func buildAssociations(structs []*structDef) {
+	byTable := map[string]*structDef{}
+	for _, def := range structs {
+		byTable[def.Table] = def
+	}
+
+	for _, def := range structs {
+		for _, foreignKey := range def.table.ForeignKeys {
+			owner, ok := byTable[foreignKey.ReferencedTable]
+			if !ok {
+				continue
+			}
+
+			var (
+				foreignField     = def.fieldOfColumn(foreignKey.Column)
+				associationField = owner.primaryKey
+			)
+			if foreignKey.ReferencedColumn != "" {
+				associationField = owner.fieldOfColumn(foreignKey.ReferencedColumn)
+			}
+
+			// belongs_to, e.g: `Company Company` for `company_id`
+			name := owner.Name
+			if strings.HasSuffix(foreignKey.Column, "_id") {
+				name = toCamel(strings.TrimSuffix(foreignKey.Column, "_id"))
+			}
+			name = def.uniqueFieldName(name)
+
+			var settings []string
+			if foreignField != name+owner.primaryKey {
+				settings = append(settings, "foreignkey:"+foreignField)
+			}
+			if associationField != owner.primaryKey {
+				settings = append(settings, "association_foreignkey:"+associationField)
+			}
+
+			typ := owner.Name
+			if owner == def {
+				typ = "*" + typ
+			}
+			def.Fields = append(def.Fields, fieldDef{Name: name, Type: typ, Tag: associationTag(settings)})
+
+			// has_many, e.g: `Users []User` for `users.company_id`
+			hasManyName := inflection.Plural(def.Name)
+			if owner.hasField(hasManyName) {
+				hasManyName = owner.uniqueFieldName(name + hasManyName)
+			}
+
+			settings = nil
+			if foreignField != owner.Name+owner.primaryKey {
+				settings = append(settings, "foreignkey:"+foreignField)
+			}
+			if associationField != owner.primaryKey {
+				settings = append(settings, "association_foreignkey:"+associationField)
+			}
+			owner.Fields = append(owner.Fields, fieldDef{Name: hasManyName, Type: "[]" + def.Name, Tag: associationTag(settings)})
+		}
+	}
+}
+
+func associationTag(settings []string) string {
+	if len(settings) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("`gorm:\"%v\"`", strings.Join(settings, ";"))
+}
+
+var codeTemplate = template.Must(template.New("gorm-introspect").Parse(`// Code generated by gorm-introspect. DO NOT EDIT.
+
+package {{.Package}}
+{{if .Imports}}
+import (
+{{- range .Imports}}
+	{{.}}
+{{- end}}
+)
+{{end}}
+{{- range .Structs}}
+// {{.Name}} model of table {{.Table}}
+type {{.Name}} struct {
+{{- if .EmbedModel}}
+	gorm.Model
+{{- end}}
+{{- range .Fields}}
+	{{.Name}} {{.Type}} {{.Tag}}
+{{- end}}
+}
+{{if .TableName}}
+// TableName table name of {{.Name}}
+func ({{.Name}}) TableName() string {
+	return {{printf "%q" .Table}}
+}
+{{end}}
+{{- end}}`))
+
+// generate generate Go structs of tables
+func generate(packageName string, tables []*Table) ([]byte, error) {
+	structs, usesTime := buildStructs(tables)
+	buildAssociations(structs)
+
+	var imports []string
+	if usesTime {
+		imports = append(imports, `"time"`)
+	}
+	for _, def := range structs {
+		if def.EmbedModel {
+			if len(imports) > 0 {
+				imports = append(imports, "")
+			}
+			imports = append(imports, `"github.com/zhinanxing/gorm/v3"`)
+			break
+		}
+	}
+
+	sort.SliceStable(structs, func(i, j int) bool { return structs[i].Name < structs[j].Name })
+
+	var buf bytes.Buffer
+	if err := codeTemplate.Execute(&buf, map[string]interface{}{"Package": packageName, "Imports": imports, "Structs": structs}); err != nil {
+		return nil, err
+	}
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %v\n%s", err, buf.String())
+	}
+	return code, nil
+}
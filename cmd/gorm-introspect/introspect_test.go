@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zhinanxing/gorm/v3"
+)
+
+func TestIntrospect(t *testing.T) {
+	db, err := gorm.Open("sqlite3", filepath.Join(t.TempDir(), "legacy.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database, got %v", err)
+	}
+	defer db.Close()
+
+	for _, sql := range []string{
+		`CREATE TABLE companies (id integer PRIMARY KEY, created_at datetime, updated_at datetime, extra_json text, deleted_at datetime, name varchar(100) NOT NULL UNIQUE)`,
+		`CREATE TABLE users (id integer PRIMARY KEY, company_id integer REFERENCES companies(id), manager_id integer REFERENCES users, email varchar(255) NOT NULL, nick_name text DEFAULT 'none', birthday date, avatar blob, score real)`,
+		`CREATE UNIQUE INDEX idx_users_email ON users(email)`,
+		`CREATE INDEX idx_users_company_nick ON users(company_id, nick_name)`,
+		`CREATE TABLE tbl_audit (audit_key varchar(36) PRIMARY KEY, actor_id integer NOT NULL, FOREIGN KEY(actor_id) REFERENCES users(id))`,
+	} {
+		if err := db.Exec(sql).Error(); err != nil {
+			t.Fatalf("Failed to create tables, got %v", err)
+		}
+	}
+
+	tables, err := introspect(db)
+	if err != nil {
+		t.Fatalf("No error should happen when introspect, but got %v", err)
+	}
+
+	if len(tables) != 3 || tables[0].Name != "companies" || tables[1].Name != "tbl_audit" || tables[2].Name != "users" {
+		t.Fatalf("Should read all tables, but got %#v", tables)
+	}
+
+	users := tables[2]
+	if len(users.Columns) != 8 || !users.Columns[0].PrimaryKey || users.Columns[3].Nullable || !users.Columns[4].Nullable {
+		t.Errorf("Should read columns of users, but got %#v", users.Columns)
+	}
+
+	if len(users.Indexes) != 2 || len(users.ForeignKeys) != 2 {
+		t.Errorf("Should read indexes and foreign keys of users, but got %#v, %#v", users.Indexes, users.ForeignKeys)
+	}
+
+	code, err := generate("models", tables)
+	if err != nil {
+		t.Fatalf("No error should happen when generate structs, but got %v", err)
+	}
+
+	for _, expected := range []string{
+		"package models",
+		"type Company struct {\n\tgorm.Model\n",
+		"Name  string `gorm:\"size:100;not null;unique\"`",
+		"Users []User",
+		"CompanyID *int64 `gorm:\"index:idx_users_company_nick\"`",
+		"Email     string `gorm:\"size:255;not null;unique_index:idx_users_email\"`",
+		"NickName  *string `gorm:\"default:'none';index:idx_users_company_nick\"`",
+		"Birthday  *time.Time",
+		"Avatar    []byte",
+		"Score     *float64",
+		"Company Company }",
+		"Manager *User Users",
+		"Users     []User `gorm:\"foreignkey:ManagerID\"`",
+		"TblAudits []TblAudit `gorm:\"foreignkey:ActorID\"`",
+		"AuditKey string `gorm:\"primary_key;size:36\"`",
+		"Actor    User",
+		"func (TblAudit) TableName() string {\n\treturn \"tbl_audit\"\n}",
+	} {
+		// ignore alignment of gofmt
+		if !strings.Contains(strings.Join(strings.Fields(string(code)), " "), strings.Join(strings.Fields(expected), " ")) {
+			t.Errorf("Generated code should contain %q, but got\n%s", expected, code)
+		}
+	}
+
+	typeCheck(t, code)
+
+	if _, err := introspect(db, "unknown_table"); err == nil {
+		t.Errorf("Should return error for unknown table")
+	}
+}
+
+func TestIntrospectCompositeForeignKey(t *testing.T) {
+	db, err := gorm.Open("sqlite3", filepath.Join(t.TempDir(), "composite.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database, got %v", err)
+	}
+	defer db.Close()
+
+	for _, sql := range []string{
+		`CREATE TABLE accounts (region varchar(10), code varchar(10), owner_id integer, PRIMARY KEY (region, code))`,
+		`CREATE TABLE owners (id integer PRIMARY KEY)`,
+		`CREATE TABLE transfers (id integer PRIMARY KEY, region varchar(10), code varchar(10), owner_id integer REFERENCES owners(id),
+			FOREIGN KEY(region, code) REFERENCES accounts(region, code))`,
+	} {
+		if err := db.Exec(sql).Error(); err != nil {
+			t.Fatalf("Failed to create tables, got %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	tables, err := introspect(db, "transfers")
+	if err != nil {
+		t.Fatalf("No error should happen when introspect, but got %v", err)
+	}
+
+	if foreignKeys := tables[0].ForeignKeys; len(foreignKeys) != 1 || foreignKeys[0].Column != "owner_id" {
+		t.Errorf("Should only keep single column foreign keys, but got %#v", foreignKeys)
+	}
+
+	if !strings.Contains(buf.String(), "skipping composite foreign key (region, code) of table transfers to accounts") {
+		t.Errorf("Should warn about skipped composite foreign key, but got %q", buf.String())
+	}
+}
+
+func TestGoType(t *testing.T) {
+	for databaseType, expected := range map[string]string{
+		"integer": "int64", "int(11)": "int64", "bigint unsigned": "int64", "smallint": "int64", "mediumint": "int64", "int8": "int64",
+		"bigserial": "int64", "UNSIGNED BIG INT": "int64", "tinyint(1)": "bool", "interval": "string", "point": "string",
+		"timestamp with time zone": "time.Time", "varchar(20)": "string", "numeric(10,2)": "float64",
+	} {
+		if typ, _ := goType(&Column{Type: databaseType}); typ != expected {
+			t.Errorf("Go type of %v should be %v, but got %v", databaseType, expected, typ)
+		}
+	}
+}
+
+// typeCheck check the generated code compiles
+func typeCheck(t *testing.T, code []byte) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "models.go", code, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse generated code, got %v", err)
+	}
+
+	config := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := config.Check("models", fset, []*ast.File{file}, nil); err != nil {
+		t.Errorf("Generated code should compile, but got %v\n%s", err, code)
+	}
+}
+
+func TestIntrospectDuplicatedAssociations(t *testing.T) {
+	db, err := gorm.Open("sqlite3", filepath.Join(t.TempDir(), "duplicated.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database, got %v", err)
+	}
+	defer db.Close()
+
+	for _, sql := range []string{
+		`CREATE TABLE users (id integer PRIMARY KEY, name text)`,
+		`CREATE TABLE articles (id integer PRIMARY KEY, author integer REFERENCES users(id), editor integer REFERENCES users(id), reviewer integer REFERENCES users(id))`,
+	} {
+		if err := db.Exec(sql).Error(); err != nil {
+			t.Fatalf("Failed to create tables, got %v", err)
+		}
+	}
+
+	tables, err := introspect(db)
+	if err != nil {
+		t.Fatalf("No error should happen when introspect, but got %v", err)
+	}
+
+	code, err := generate("models", tables)
+	if err != nil {
+		t.Fatalf("No error should happen when generate structs, but got %v", err)
+	}
+
+	for _, expected := range []string{"UserRef2 User", "UserRef2Articles []Article"} {
+		if !strings.Contains(strings.Join(strings.Fields(string(code)), " "), expected) {
+			t.Errorf("Generated code should contain %q, but got\n%s", expected, code)
+		}
+	}
+	typeCheck(t, code)
+}
+
+// TestIntrospectServer read tables from the database server of GORM_DIALECT and GORM_DSN, the same as tests of gorm
+func TestIntrospectServer(t *testing.T) {
+	dialect, dsn := os.Getenv("GORM_DIALECT"), os.Getenv("GORM_DSN")
+	if dialect == "" || dsn == "" {
+		t.Skip("GORM_DIALECT and GORM_DSN aren't set")
+	}
+
+	db, err := gorm.Open(dialect, dsn)
+	if err != nil {
+		t.Fatalf("Failed to open database, got %v", err)
+	}
+	defer db.Close()
+
+	db.DropTableIfExists("introspect_items", "introspect_owners")
+	for _, sql := range []string{
+		`CREATE TABLE introspect_owners (id integer PRIMARY KEY, name varchar(100) NOT NULL)`,
+		`CREATE TABLE introspect_items (id integer PRIMARY KEY, owner_id integer, title varchar(50), FOREIGN KEY (owner_id) REFERENCES introspect_owners(id))`,
+		`CREATE INDEX idx_introspect_items_title ON introspect_items(title)`,
+	} {
+		if err := db.Exec(sql).Error(); err != nil {
+			t.Fatalf("Failed to create tables, got %v", err)
+		}
+	}
+	defer db.DropTableIfExists("introspect_items", "introspect_owners")
+
+	tables, err := introspect(db, "introspect_owners", "introspect_items")
+	if err != nil {
+		t.Fatalf("No error should happen when introspect, but got %v", err)
+	}
+
+	items := tables[0]
+	if len(items.Columns) != 3 || !items.Columns[0].PrimaryKey || !items.Columns[1].Nullable || !strings.HasSuffix(items.Columns[2].Type, "(50)") {
+		t.Errorf("Should read columns of items, but got %#v", items.Columns)
+	}
+
+	var titleIndex *Index
+	for _, index := range items.Indexes {
+		if index.Name == "idx_introspect_items_title" {
+			titleIndex = index
+		}
+	}
+	if titleIndex == nil || titleIndex.Unique || !reflect.DeepEqual(titleIndex.Columns, []string{"title"}) {
+		t.Errorf("Should read indexes of items, but got %#v", items.Indexes)
+	}
+
+	if len(items.ForeignKeys) != 1 || *items.ForeignKeys[0] != (ForeignKey{Column: "owner_id", ReferencedTable: "introspect_owners", ReferencedColumn: "id"}) {
+		t.Errorf("Should read foreign keys of items, but got %#v", items.ForeignKeys)
+	}
+
+	code, err := generate("models", tables)
+	if err != nil {
+		t.Fatalf("No error should happen when generate structs, but got %v", err)
+	}
+	typeCheck(t, code)
+}
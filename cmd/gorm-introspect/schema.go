@@ -0,0 +1,479 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zhinanxing/gorm/v3"
+)
+
+// Table a table read from the database
+type Table struct {
+	Name        string
+	Columns     []*Column
+	Indexes     []*Index
+	ForeignKeys []*ForeignKey
+}
+
+// Column a column of the table
+type Column struct {
+	Name       string
+	Type       string // database type, e.g: `varchar(255)`
+	Nullable   bool
+	PrimaryKey bool
+	Default    *string
+}
+
+// Index an index of the table, primary keys are excluded
+type Index struct {
+	Name     string
+	Unique   bool
+	Implicit bool // created by an `UNIQUE` constraint, the name is generated by the database
+	Columns  []string
+}
+
+// ForeignKey a single column foreign key of the table
+type ForeignKey struct {
+	Column           string
+	ReferencedTable  string
+	ReferencedColumn string
+}
+
+// introspector read tables of a dialect
+type introspector interface {
+	tables(db gorm.Repository) ([]string, error)
+	table(db gorm.Repository, name string) (*Table, error)
+}
+
+var introspectors = map[string]introspector{
+	"sqlite3":  sqliteIntrospector{},
+	"mysql":    mysqlIntrospector{},
+	"postgres": postgresIntrospector{},
+	"mssql":    mssqlIntrospector{},
+}
+
+// introspect read tables from the database, all tables are read if no names given
+func introspect(db gorm.Repository, names ...string) ([]*Table, error) {
+	dialect := db.Dialect().GetName()
+	reader, ok := introspectors[dialect]
+	if !ok {
+		return nil, fmt.Errorf("introspection of %v isn't supported", dialect)
+	}
+
+	if len(names) == 0 {
+		var err error
+		if names, err = reader.tables(db); err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(names)
+
+	var tables []*Table
+	for _, name := range names {
+		table, err := reader.table(db, name)
+		if err != nil {
+			return nil, fmt.Errorf("reading table %v: %v", name, err)
+		}
+
+		if len(table.Columns) == 0 {
+			return nil, fmt.Errorf("can't find table %v", name)
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+// queryStrings query the first column of rows as strings
+func queryStrings(db gorm.Repository, sql string, values ...interface{}) ([]string, error) {
+	rows, err := db.Raw(sql, values...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		results = append(results, value)
+	}
+	return results, rows.Err()
+}
+
+type sqliteIntrospector struct{}
+
+func (sqliteIntrospector) tables(db gorm.Repository) ([]string, error) {
+	return queryStrings(db, "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'")
+}
+
+func (sqliteIntrospector) table(db gorm.Repository, name string) (*Table, error) {
+	table := &Table{Name: name}
+	quoted := db.Dialect().Quote(name)
+
+	rows, err := db.Raw(fmt.Sprintf("PRAGMA table_info(%v)", quoted)).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid, notNull, pk int
+			column           = &Column{}
+			defaultValue     *string
+		)
+		if err := rows.Scan(&cid, &column.Name, &column.Type, &notNull, &defaultValue, &pk); err != nil {
+			return nil, err
+		}
+		column.Nullable = notNull == 0 && pk == 0
+		column.PrimaryKey = pk > 0
+		column.Default = defaultValue
+		table.Columns = append(table.Columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	indexRows, err := db.Raw(fmt.Sprintf("PRAGMA index_list(%v)", quoted)).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer indexRows.Close()
+
+	columns, _ := indexRows.Columns()
+	for indexRows.Next() {
+		var (
+			index  = &Index{}
+			origin string
+			values = make([]interface{}, len(columns))
+		)
+
+		// columns of `index_list` differ between SQLite versions: seq, name, unique[, origin, partial]
+		for idx, column := range columns {
+			switch column {
+			case "name":
+				values[idx] = &index.Name
+			case "unique":
+				values[idx] = &index.Unique
+			case "origin":
+				values[idx] = &origin
+			default:
+				values[idx] = new(interface{})
+			}
+		}
+
+		if err := indexRows.Scan(values...); err != nil {
+			return nil, err
+		}
+
+		if origin == "pk" {
+			continue
+		}
+		index.Implicit = origin == "u"
+		table.Indexes = append(table.Indexes, index)
+	}
+	if err := indexRows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, index := range table.Indexes {
+		infoRows, err := db.Raw(fmt.Sprintf("PRAGMA index_info(%v)", db.Dialect().Quote(index.Name))).Rows()
+		if err != nil {
+			return nil, err
+		}
+
+		for infoRows.Next() {
+			var (
+				seqno, cid int
+				column     string
+			)
+			if err := infoRows.Scan(&seqno, &cid, &column); err != nil {
+				infoRows.Close()
+				return nil, err
+			}
+			index.Columns = append(index.Columns, column)
+		}
+		infoRows.Close()
+	}
+
+	foreignKeyRows, err := db.Raw(fmt.Sprintf("PRAGMA foreign_key_list(%v)", quoted)).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer foreignKeyRows.Close()
+
+	var (
+		ids         []string
+		foreignKeys = map[string][]*ForeignKey{}
+	)
+	for foreignKeyRows.Next() {
+		var (
+			id, seq                   int
+			foreignKey                = &ForeignKey{}
+			referencedColumn          *string
+			onUpdate, onDelete, match string
+		)
+		if err := foreignKeyRows.Scan(&id, &seq, &foreignKey.ReferencedTable, &foreignKey.Column, &referencedColumn, &onUpdate, &onDelete, &match); err != nil {
+			return nil, err
+		}
+
+		if referencedColumn != nil {
+			foreignKey.ReferencedColumn = *referencedColumn
+		}
+
+		constraint := strconv.Itoa(id)
+		if _, ok := foreignKeys[constraint]; !ok {
+			ids = append(ids, constraint)
+		}
+		foreignKeys[constraint] = append(foreignKeys[constraint], foreignKey)
+	}
+
+	table.ForeignKeys = singleColumnForeignKeys(name, ids, foreignKeys)
+	return table, foreignKeyRows.Err()
+}
+
+// singleColumnForeignKeys return foreign keys of single column in order of constraints,
+// composite foreign keys can't be mapped to associations, they are skipped with a warning
+func singleColumnForeignKeys(table string, constraints []string, foreignKeys map[string][]*ForeignKey) (results []*ForeignKey) {
+	for _, constraint := range constraints {
+		if len(foreignKeys[constraint]) == 1 {
+			results = append(results, foreignKeys[constraint][0])
+			continue
+		}
+
+		var columns []string
+		for _, foreignKey := range foreignKeys[constraint] {
+			if !contains(columns, foreignKey.Column) {
+				columns = append(columns, foreignKey.Column)
+			}
+		}
+		log.Printf("warning: skipping composite foreign key (%v) of table %v to %v, it can't be mapped to an association",
+			strings.Join(columns, ", "), table, foreignKeys[constraint][0].ReferencedTable)
+	}
+	return results
+}
+
+// contains check if the value is in values
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// informationSchemaColumns read columns and primary keys from `information_schema`, which are the same on MySQL, PostgreSQL and SQL Server
+func informationSchemaColumns(db gorm.Repository, schema, name string) ([]*Column, error) {
+	rows, err := db.Raw(`SELECT column_name, data_type, character_maximum_length, is_nullable, column_default
+		FROM information_schema.columns WHERE table_schema = ? AND table_name = ? ORDER BY ordinal_position`, schema, name).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []*Column
+	for rows.Next() {
+		var (
+			column     = &Column{}
+			length     *int64
+			isNullable string
+		)
+		if err := rows.Scan(&column.Name, &column.Type, &length, &isNullable, &column.Default); err != nil {
+			return nil, err
+		}
+
+		if length != nil && *length > 0 {
+			column.Type = fmt.Sprintf("%v(%d)", column.Type, *length)
+		}
+		column.Nullable = strings.EqualFold(isNullable, "YES")
+		columns = append(columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	primaryKeys, err := queryStrings(db, `SELECT kcu.column_name FROM information_schema.table_constraints tc
+		INNER JOIN information_schema.key_column_usage kcu ON kcu.constraint_name = tc.constraint_name AND kcu.table_schema = tc.table_schema AND kcu.table_name = tc.table_name
+		WHERE tc.constraint_type = 'PRIMARY KEY' AND tc.table_schema = ? AND tc.table_name = ?`, schema, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, primaryKey := range primaryKeys {
+		for _, column := range columns {
+			if column.Name == primaryKey {
+				column.PrimaryKey = true
+				column.Nullable = false
+			}
+		}
+	}
+	return columns, nil
+}
+
+// scanIndexes scan rows of index name, uniqueness and column name ordered by index name and column position
+func scanIndexes(db gorm.Repository, sql string, values ...interface{}) ([]*Index, error) {
+	rows, err := db.Raw(sql, values...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var indexes []*Index
+	for rows.Next() {
+		var (
+			name, column string
+			unique       bool
+		)
+		if err := rows.Scan(&name, &unique, &column); err != nil {
+			return nil, err
+		}
+
+		if len(indexes) == 0 || indexes[len(indexes)-1].Name != name {
+			indexes = append(indexes, &Index{Name: name, Unique: unique})
+		}
+		indexes[len(indexes)-1].Columns = append(indexes[len(indexes)-1].Columns, column)
+	}
+	return indexes, rows.Err()
+}
+
+// scanForeignKeys scan rows of constraint name, column, referenced table and referenced column ordered by constraint name
+func scanForeignKeys(db gorm.Repository, table, sql string, values ...interface{}) ([]*ForeignKey, error) {
+	rows, err := db.Raw(sql, values...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		constraints []string
+		foreignKeys = map[string][]*ForeignKey{}
+	)
+	for rows.Next() {
+		var (
+			constraint string
+			foreignKey = &ForeignKey{}
+		)
+		if err := rows.Scan(&constraint, &foreignKey.Column, &foreignKey.ReferencedTable, &foreignKey.ReferencedColumn); err != nil {
+			return nil, err
+		}
+
+		if _, ok := foreignKeys[constraint]; !ok {
+			constraints = append(constraints, constraint)
+		}
+		foreignKeys[constraint] = append(foreignKeys[constraint], foreignKey)
+	}
+	return singleColumnForeignKeys(table, constraints, foreignKeys), rows.Err()
+}
+
+type mysqlIntrospector struct{}
+
+func (mysqlIntrospector) tables(db gorm.Repository) ([]string, error) {
+	return queryStrings(db, "SELECT table_name FROM information_schema.tables WHERE table_schema = ? AND table_type = 'BASE TABLE'", db.Dialect().CurrentDatabase())
+}
+
+func (mysqlIntrospector) table(db gorm.Repository, name string) (table *Table, err error) {
+	schema := db.Dialect().CurrentDatabase()
+	table = &Table{Name: name}
+	if table.Columns, err = informationSchemaColumns(db, schema, name); err != nil {
+		return nil, err
+	}
+
+	if table.Indexes, err = scanIndexes(db, `SELECT index_name, non_unique = 0, column_name FROM information_schema.statistics
+		WHERE table_schema = ? AND table_name = ? AND index_name <> 'PRIMARY' ORDER BY index_name, seq_in_index`, schema, name); err != nil {
+		return nil, err
+	}
+
+	table.ForeignKeys, err = scanForeignKeys(db, name, `SELECT constraint_name, column_name, referenced_table_name, referenced_column_name
+		FROM information_schema.key_column_usage WHERE table_schema = ? AND table_name = ? AND referenced_table_name IS NOT NULL
+		ORDER BY constraint_name, ordinal_position`, schema, name)
+	return table, err
+}
+
+type postgresIntrospector struct{}
+
+func (postgresIntrospector) tables(db gorm.Repository) ([]string, error) {
+	return queryStrings(db, "SELECT table_name FROM information_schema.tables WHERE table_schema = CURRENT_SCHEMA() AND table_type = 'BASE TABLE'")
+}
+
+func (postgresIntrospector) table(db gorm.Repository, name string) (table *Table, err error) {
+	schemas, err := queryStrings(db, "SELECT CURRENT_SCHEMA()")
+	if err != nil || len(schemas) == 0 {
+		return nil, fmt.Errorf("can't find current schema: %v", err)
+	}
+
+	table = &Table{Name: name}
+	if table.Columns, err = informationSchemaColumns(db, schemas[0], name); err != nil {
+		return nil, err
+	}
+
+	if table.Indexes, err = scanIndexes(db, `SELECT i.relname, ix.indisunique, a.attname
+		FROM pg_index ix
+		INNER JOIN pg_class t ON t.oid = ix.indrelid
+		INNER JOIN pg_class i ON i.oid = ix.indexrelid
+		INNER JOIN pg_namespace n ON n.oid = t.relnamespace
+		INNER JOIN LATERAL generate_series(0, ix.indnatts - 1) AS k(position) ON true
+		INNER JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = ix.indkey[k.position]
+		WHERE n.nspname = ? AND t.relname = ? AND NOT ix.indisprimary
+		ORDER BY i.relname, k.position`, schemas[0], name); err != nil {
+		return nil, err
+	}
+
+	table.ForeignKeys, err = scanForeignKeys(db, name, `SELECT tc.constraint_name, kcu.column_name, ccu.table_name, ccu.column_name
+		FROM information_schema.table_constraints tc
+		INNER JOIN information_schema.key_column_usage kcu ON kcu.constraint_name = tc.constraint_name AND kcu.table_schema = tc.table_schema
+		INNER JOIN information_schema.constraint_column_usage ccu ON ccu.constraint_name = tc.constraint_name AND ccu.table_schema = tc.table_schema
+		WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_schema = ? AND tc.table_name = ?
+		ORDER BY tc.constraint_name, kcu.ordinal_position`, schemas[0], name)
+	return table, err
+}
+
+type mssqlIntrospector struct{}
+
+func (mssqlIntrospector) tables(db gorm.Repository) ([]string, error) {
+	return queryStrings(db, "SELECT table_name FROM information_schema.tables WHERE table_schema = SCHEMA_NAME() AND table_type = 'BASE TABLE'")
+}
+
+func (mssqlIntrospector) table(db gorm.Repository, name string) (table *Table, err error) {
+	schemas, err := queryStrings(db, "SELECT SCHEMA_NAME()")
+	if err != nil || len(schemas) == 0 {
+		return nil, fmt.Errorf("can't find current schema: %v", err)
+	}
+
+	table = &Table{Name: name}
+	if table.Columns, err = informationSchemaColumns(db, schemas[0], name); err != nil {
+		return nil, err
+	}
+
+	// defaults are wrapped in parentheses, e.g: `((0))`, `('none')`
+	for _, column := range table.Columns {
+		for column.Default != nil && strings.HasPrefix(*column.Default, "(") && strings.HasSuffix(*column.Default, ")") {
+			value := (*column.Default)[1 : len(*column.Default)-1]
+			column.Default = &value
+		}
+	}
+
+	if table.Indexes, err = scanIndexes(db, `SELECT i.name, i.is_unique, c.name
+		FROM sys.indexes i
+		INNER JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+		INNER JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+		WHERE i.object_id = OBJECT_ID(QUOTENAME(?) + '.' + QUOTENAME(?)) AND i.is_primary_key = 0 AND ic.is_included_column = 0
+		ORDER BY i.name, ic.key_ordinal`, schemas[0], name); err != nil {
+		return nil, err
+	}
+
+	table.ForeignKeys, err = scanForeignKeys(db, name, `SELECT fk.name, pc.name, rt.name, rc.name
+		FROM sys.foreign_keys fk
+		INNER JOIN sys.foreign_key_columns fkc ON fkc.constraint_object_id = fk.object_id
+		INNER JOIN sys.columns pc ON pc.object_id = fkc.parent_object_id AND pc.column_id = fkc.parent_column_id
+		INNER JOIN sys.tables rt ON rt.object_id = fkc.referenced_object_id
+		INNER JOIN sys.columns rc ON rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id
+		WHERE fk.parent_object_id = OBJECT_ID(QUOTENAME(?) + '.' + QUOTENAME(?))
+		ORDER BY fk.name, fkc.constraint_column_id`, schemas[0], name)
+	return table, err
+}
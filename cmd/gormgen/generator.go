@@ -0,0 +1,440 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// model columns and associations of a model struct, loaded with `GetModelStruct`
+type model struct {
+	Name         string
+	Columns      []column
+	Associations []string
+}
+
+// column a normal field of the model
+type column struct {
+	Field      string
+	DBName     string
+	Type       string
+	HandleType string
+}
+
+// columnHandle a typed handle of columns with the same Go type
+type columnHandle struct {
+	Name     string
+	Type     string
+	IsString bool
+}
+
+// loaded models loaded by the loader program, Imports are packages used by column types, name => path
+type loaded struct {
+	Models  []*model
+	Imports map[string]string
+}
+
+// loaderTemplate program imports the package of models and prints their `GetModelStruct` as JSON,
+// so columns and associations are the same as what gorm uses at runtime
+var loaderTemplate = template.Must(template.New("loader").Parse(`package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	gorm "github.com/zhinanxing/gorm/v3"
+	target {{printf "%q" .ImportPath}}
+)
+
+type column struct {
+	Field  string
+	DBName string
+	Type   string
+}
+
+type model struct {
+	Name         string
+	Columns      []column
+	Associations []string
+}
+
+var imports = map[string]string{}
+
+// typeString render the type as Go code in the package of models
+func typeString(t reflect.Type) string {
+	switch {
+	case t.Name() != "" && t.PkgPath() == {{printf "%q" .ImportPath}}:
+		return t.Name()
+	case t.Name() != "" && t.PkgPath() != "":
+		name := t.String()
+		imports[name[:strings.Index(name, ".")]] = t.PkgPath()
+		return name
+	case t.Kind() == reflect.Ptr:
+		return "*" + typeString(t.Elem())
+	case t.Kind() == reflect.Slice && t.Elem() == reflect.TypeOf(byte(0)):
+		return "[]byte"
+	case t.Kind() == reflect.Slice:
+		return "[]" + typeString(t.Elem())
+	case t.Kind() == reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), typeString(t.Elem()))
+	case t.Kind() == reflect.Map:
+		return fmt.Sprintf("map[%s]%s", typeString(t.Key()), typeString(t.Elem()))
+	}
+	return t.String()
+}
+
+func main() {
+	var models []model
+	for _, value := range []interface{}{ {{- range .Names}}&target.{{.}}{}, {{end -}} } {
+		modelStruct := (&gorm.Scope{Value: value}).GetModelStruct()
+		m := model{Name: modelStruct.ModelType.Name()}
+		for _, field := range modelStruct.StructFields {
+			switch {
+			case field.IsIgnored:
+			case field.Relationship != nil:
+				m.Associations = append(m.Associations, field.Name)
+			case field.IsNormal:
+				fieldType := field.Struct.Type
+				for fieldType.Kind() == reflect.Ptr {
+					fieldType = fieldType.Elem()
+				}
+				m.Columns = append(m.Columns, column{Field: field.Name, DBName: field.DBName, Type: typeString(fieldType)})
+			}
+		}
+		models = append(models, m)
+	}
+
+	if err := json.NewEncoder(os.Stdout).Encode(map[string]interface{}{"Models": models, "Imports": imports}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+`))
+
+// goCommand run the go command in dir, return its output
+func goCommand(dir string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("go", args...)
+	cmd.Dir, cmd.Stderr = dir, &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go %s: %v\n%s", strings.Join(args, " "), err, stderr.String())
+	}
+	return output, nil
+}
+
+// loadModels build and run the loader program for models in the package of dir
+func loadModels(dir string, names []string) (packageName string, result *loaded, err error) {
+	output, err := goCommand(dir, "list", "-f", "{{.ImportPath}} {{.Name}}", ".")
+	if err != nil {
+		return "", nil, err
+	}
+
+	fields := strings.Fields(string(output))
+	if len(fields) != 2 {
+		return "", nil, fmt.Errorf("can't find the package in %s", dir)
+	}
+
+	for _, name := range names {
+		if !token.IsIdentifier(name) || !token.IsExported(name) {
+			return "", nil, fmt.Errorf("invalid model name %q", name)
+		}
+	}
+
+	tmpDir, err := ioutil.TempDir("", "gormgen")
+	if err != nil {
+		return "", nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	var program bytes.Buffer
+	if err := loaderTemplate.Execute(&program, map[string]interface{}{"ImportPath": fields[0], "Names": names}); err != nil {
+		return "", nil, err
+	}
+
+	loader := filepath.Join(tmpDir, "main.go")
+	if err := ioutil.WriteFile(loader, program.Bytes(), 0644); err != nil {
+		return "", nil, err
+	}
+
+	// run in dir, so the loader is built with the module of models
+	if output, err = goCommand(dir, "run", loader); err != nil {
+		return "", nil, fmt.Errorf("loading models: %v", err)
+	}
+
+	result = &loaded{}
+	if err := json.Unmarshal(output, result); err != nil {
+		return "", nil, fmt.Errorf("loading models: %v", err)
+	}
+	return fields[1], result, nil
+}
+
+// handleName name of the column handle type, e.g: `userTimeTimeColumn`
+func handleName(modelName, typeName string) string {
+	var name []rune
+	upper := true
+	for _, r := range typeName {
+		switch {
+		case r == '[':
+			name = append(name, []rune("Slice")...)
+			upper = true
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if upper {
+				r = unicode.ToUpper(r)
+			}
+			name = append(name, r)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+
+	modelRunes := []rune(modelName)
+	modelRunes[0] = unicode.ToLower(modelRunes[0])
+	return string(modelRunes) + string(name) + "Column"
+}
+
+var codeTemplate = template.Must(template.New("gormgen").Parse(`// Code generated by gormgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .Imports}}
+{{- if .}}
+	{{.}}
+{{- else}}
+{{end}}
+{{- end}}
+)
+{{range $model := .Models}}
+// {{$model.Name}}Cols typed handles of {{$model.Name}}'s columns
+var {{$model.Name}}Cols = struct {
+{{- range $model.Columns}}
+	{{.Field}} {{.HandleType}}
+{{- end}}
+}{
+{{- range $model.Columns}}
+	{{.Field}}: {{printf "%q" .DBName}},
+{{- end}}
+}
+{{range $model.Handles}}
+// {{.Name}} handle of {{$model.Name}}'s {{.Type}} columns
+type {{.Name}} string
+
+// Name return the column name
+func (c {{.Name}}) Name() string { return string(c) }
+
+// Asc order by the column ascending
+func (c {{.Name}}) Asc() string { return string(c) + " ASC" }
+
+// Desc order by the column descending
+func (c {{.Name}}) Desc() string { return string(c) + " DESC" }
+
+// Eq column equals to value
+func (c {{.Name}}) Eq(value {{.Type}}) *gorm.Condition { return gorm.Cond.Eq(string(c), value) }
+
+// Neq column doesn't equal to value
+func (c {{.Name}}) Neq(value {{.Type}}) *gorm.Condition { return gorm.Cond.Neq(string(c), value) }
+
+// Gt column is greater than value
+func (c {{.Name}}) Gt(value {{.Type}}) *gorm.Condition { return gorm.Cond.Gt(string(c), value) }
+
+// Gte column is greater than or equal to value
+func (c {{.Name}}) Gte(value {{.Type}}) *gorm.Condition { return gorm.Cond.Gte(string(c), value) }
+
+// Lt column is less than value
+func (c {{.Name}}) Lt(value {{.Type}}) *gorm.Condition { return gorm.Cond.Lt(string(c), value) }
+
+// Lte column is less than or equal to value
+func (c {{.Name}}) Lte(value {{.Type}}) *gorm.Condition { return gorm.Cond.Lte(string(c), value) }
+
+// Between column is between from and to
+func (c {{.Name}}) Between(from, to {{.Type}}) *gorm.Condition { return gorm.Cond.Between(string(c), from, to) }
+
+// In column is one of values
+func (c {{.Name}}) In(values ...{{.Type}}) *gorm.Condition { return gorm.Cond.In(string(c), values) }
+
+// NotIn column isn't any of values
+func (c {{.Name}}) NotIn(values ...{{.Type}}) *gorm.Condition { return gorm.Cond.NotIn(string(c), values) }
+
+// IsNull column is NULL
+func (c {{.Name}}) IsNull() *gorm.Condition { return gorm.Cond.IsNull(string(c)) }
+
+// IsNotNull column isn't NULL
+func (c {{.Name}}) IsNotNull() *gorm.Condition { return gorm.Cond.IsNotNull(string(c)) }
+{{- if .IsString}}
+
+// Like column matches the pattern
+func (c {{.Name}}) Like(pattern string) *gorm.Condition { return gorm.Cond.Like(string(c), pattern) }
+{{- end}}
+{{end}}
+// {{$model.Name}}Query typed queries of {{$model.Name}}
+type {{$model.Name}}Query struct {
+	db gorm.Repository
+}
+
+// New{{$model.Name}}Query create typed queries of {{$model.Name}} with db
+func New{{$model.Name}}Query(db gorm.Repository) {{$model.Name}}Query {
+	return {{$model.Name}}Query{db: db.Model(&{{$model.Name}}{})}
+}
+
+// DB return the underlying repository
+func (q {{$model.Name}}Query) DB() gorm.Repository { return q.db }
+
+// Where filter records matching all conditions
+func (q {{$model.Name}}Query) Where(conditions ...*gorm.Condition) {{$model.Name}}Query {
+	db := q.db
+	for _, condition := range conditions {
+		db = db.Where(condition)
+	}
+	return {{$model.Name}}Query{db: db}
+}
+
+// Or filter records matching before conditions or this one
+func (q {{$model.Name}}Query) Or(condition *gorm.Condition) {{$model.Name}}Query {
+	return {{$model.Name}}Query{db: q.db.Or(condition)}
+}
+
+// Not filter records not matching the condition
+func (q {{$model.Name}}Query) Not(condition *gorm.Condition) {{$model.Name}}Query {
+	return {{$model.Name}}Query{db: q.db.Not(condition)}
+}
+
+// Order order records, e.g: ` + "`{{$model.Name}}Cols.{{(index $model.Columns 0).Field}}.Desc()`" + `
+func (q {{$model.Name}}Query) Order(orders ...string) {{$model.Name}}Query {
+	db := q.db
+	for _, order := range orders {
+		db = db.Order(order)
+	}
+	return {{$model.Name}}Query{db: db}
+}
+
+// Limit limit the number of records
+func (q {{$model.Name}}Query) Limit(limit int) {{$model.Name}}Query {
+	return {{$model.Name}}Query{db: q.db.Limit(limit)}
+}
+
+// Offset skip records before
+func (q {{$model.Name}}Query) Offset(offset int) {{$model.Name}}Query {
+	return {{$model.Name}}Query{db: q.db.Offset(offset)}
+}
+{{range $model.Associations}}
+// Preload{{.}} preload {{.}} of found records
+func (q {{$model.Name}}Query) Preload{{.}}(conditions ...interface{}) {{$model.Name}}Query {
+	return {{$model.Name}}Query{db: q.db.Preload({{printf "%q" .}}, conditions...)}
+}
+{{end}}
+// Find find records
+func (q {{$model.Name}}Query) Find() ([]{{$model.Name}}, error) {
+	var results []{{$model.Name}}
+	err := q.db.Find(&results).Error()
+	return results, err
+}
+
+// First find the first record ordered by primary key
+func (q {{$model.Name}}Query) First() ({{$model.Name}}, error) {
+	var result {{$model.Name}}
+	err := q.db.First(&result).Error()
+	return result, err
+}
+
+// Count count matched records
+func (q {{$model.Name}}Query) Count() (int64, error) {
+	var count int64
+	err := q.db.Count(&count).Error()
+	return count, err
+}
+
+// Delete delete matched records
+func (q {{$model.Name}}Query) Delete() error {
+	return q.db.Delete(&{{$model.Name}}{}).Error()
+}
+{{end}}`))
+
+type templateModel struct {
+	*model
+	Handles []columnHandle
+}
+
+// generate generate code of models in the directory
+func generate(dir string, names []string) ([]byte, error) {
+	for idx := range names {
+		names[idx] = strings.TrimSpace(names[idx])
+	}
+
+	packageName, result, err := loadModels(dir, names)
+	if err != nil {
+		return nil, err
+	}
+
+	var models []templateModel
+	for _, m := range result.Models {
+		if len(m.Columns) == 0 {
+			return nil, fmt.Errorf("no columns found in %s", m.Name)
+		}
+
+		handles := map[string]columnHandle{}
+		for idx, column := range m.Columns {
+			m.Columns[idx].HandleType = handleName(m.Name, column.Type)
+			handles[m.Columns[idx].HandleType] = columnHandle{Name: m.Columns[idx].HandleType, Type: column.Type, IsString: column.Type == "string"}
+		}
+
+		tm := templateModel{model: m}
+		for _, handle := range handles {
+			tm.Handles = append(tm.Handles, handle)
+		}
+		sort.Slice(tm.Handles, func(i, j int) bool { return tm.Handles[i].Name < tm.Handles[j].Name })
+		models = append(models, tm)
+	}
+
+	// standard packages are grouped before others
+	used := result.Imports
+	if used == nil {
+		used = map[string]string{}
+	}
+	used["gorm"] = "github.com/zhinanxing/gorm/v3"
+
+	var stdImports, imports []string
+	for name, path := range used {
+		spec := strconv.Quote(path)
+		if path[strings.LastIndex(path, "/")+1:] != name && name != "gorm" {
+			spec = name + " " + spec
+		}
+
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			imports = append(imports, spec)
+		} else {
+			stdImports = append(stdImports, spec)
+		}
+	}
+	sort.Strings(stdImports)
+	sort.Strings(imports)
+	if len(stdImports) > 0 {
+		imports = append(append(stdImports, ""), imports...)
+	}
+
+	var buf bytes.Buffer
+	if err := codeTemplate.Execute(&buf, map[string]interface{}{"Package": packageName, "Imports": imports, "Models": models}); err != nil {
+		return nil, err
+	}
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %v\n%s", err, buf.String())
+	}
+	return code, nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	code, err := generate("testdata/models", []string{"User", "Email"})
+	if err != nil {
+		t.Fatalf("No error should happen when generate code, but got %v", err)
+	}
+
+	golden, err := ioutil.ReadFile("testdata/models/user_gorm.go")
+	if err != nil {
+		t.Fatalf("Failed to read golden file, got %v", err)
+	}
+
+	if !bytes.Equal(code, golden) {
+		t.Errorf("Generated code should equal to testdata/models/user_gorm.go, regenerate it with `go run . -type=User,Email ./testdata/models`")
+	}
+
+	for _, expected := range []string{
+		`Email:     "email_address",`,
+		`ExtraJson: "extra_json",`,
+		`func (c userStringColumn) Like(pattern string) *gorm.Condition`,
+		`func (c userTimeTimeColumn) Gt(value time.Time) *gorm.Condition`,
+		`func (q UserQuery) PreloadEmails(conditions ...interface{}) UserQuery`,
+	} {
+		if !bytes.Contains(code, []byte(expected)) {
+			t.Errorf("Generated code should contain %v", expected)
+		}
+	}
+
+	for _, unexpected := range []string{"Ignored", "internal", "Extra ", "Emails userSlice", "Company  "} {
+		if bytes.Contains(code, []byte(unexpected)) {
+			t.Errorf("Generated code shouldn't contain %v", unexpected)
+		}
+	}
+
+	if _, err := generate("testdata/models", []string{"Unknown"}); err == nil {
+		t.Errorf("Should return error for unknown model")
+	}
+}
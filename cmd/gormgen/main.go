@@ -0,0 +1,64 @@
+// Command gormgen generates typed column handles and finders for gorm models, so renaming a column breaks compilation
+// instead of producing SQL errors at runtime, use it with `go generate`:
+//     //go:generate gormgen -type=User,Email
+//
+// For model `User`, it generates `UserCols` with a handle for each column, and `UserQuery` wrapping `gorm.Repository`:
+//     users, err := NewUserQuery(db).Where(UserCols.Age.Gt(18), UserCols.Name.Like("a%")).Order(UserCols.Id.Desc()).Find()
+//
+// Models are loaded by building a small program in the package's module that reads them with `GetModelStruct`,
+// so columns, names and associations are the same as gorm uses at runtime.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	typeNames = flag.String("type", "", "comma-separated list of model names; must be set")
+	output    = flag.String("output", "", "output file name; default <dir>/<type>_gorm.go")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of gormgen:\n")
+	fmt.Fprintf(os.Stderr, "\tgormgen [flags] -type T [directory]\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gormgen: ")
+	flag.Usage = usage
+	flag.Parse()
+
+	if len(*typeNames) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+
+	names := strings.Split(*typeNames, ",")
+	src, err := generate(dir, names)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	outputName := *output
+	if outputName == "" {
+		outputName = filepath.Join(dir, strings.ToLower(names[0])+"_gorm.go")
+	}
+
+	if err := ioutil.WriteFile(outputName, src, 0644); err != nil {
+		log.Fatalf("writing output: %s", err)
+	}
+}
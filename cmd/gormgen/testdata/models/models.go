@@ -0,0 +1,49 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/zhinanxing/gorm/v3"
+)
+
+type Role string
+
+type Tags struct {
+	Values []string
+}
+
+func (t *Tags) Scan(value interface{}) error { return nil }
+
+type Audit struct {
+	CreatedBy string
+}
+
+type User struct {
+	gorm.Model
+	Audit
+	Name      string `gorm:"size:255"`
+	Age       int64
+	Email     *string `gorm:"column:email_address"`
+	Birthday  *time.Time
+	Role      Role
+	Tags      Tags
+	Score     sql.NullInt64
+	Avatar    []byte
+	Emails    []Email
+	Company   *Company
+	CompanyID int
+	Ignored   string `sql:"-"`
+	internal  int
+}
+
+type Email struct {
+	Id     int64
+	UserId int64
+	Email  string
+}
+
+type Company struct {
+	Id   int64
+	Name string
+}
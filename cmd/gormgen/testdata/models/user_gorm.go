@@ -0,0 +1,760 @@
+// Code generated by gormgen. DO NOT EDIT.
+
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/zhinanxing/gorm/v3"
+)
+
+// UserCols typed handles of User's columns
+var UserCols = struct {
+	ID        userInt64Column
+	CreatedAt userTimeTimeColumn
+	UpdatedAt userTimeTimeColumn
+	ExtraJson userStringColumn
+	DeletedAt userTimeTimeColumn
+	CreatedBy userStringColumn
+	Name      userStringColumn
+	Age       userInt64Column
+	Email     userStringColumn
+	Birthday  userTimeTimeColumn
+	Role      userRoleColumn
+	Tags      userTagsColumn
+	Score     userSqlNullInt64Column
+	Avatar    userSliceByteColumn
+	CompanyID userIntColumn
+}{
+	ID:        "id",
+	CreatedAt: "created_at",
+	UpdatedAt: "updated_at",
+	ExtraJson: "extra_json",
+	DeletedAt: "deleted_at",
+	CreatedBy: "created_by",
+	Name:      "name",
+	Age:       "age",
+	Email:     "email_address",
+	Birthday:  "birthday",
+	Role:      "role",
+	Tags:      "tags",
+	Score:     "score",
+	Avatar:    "avatar",
+	CompanyID: "company_id",
+}
+
+// userInt64Column handle of User's int64 columns
+type userInt64Column string
+
+// Name return the column name
+func (c userInt64Column) Name() string { return string(c) }
+
+// Asc order by the column ascending
+func (c userInt64Column) Asc() string { return string(c) + " ASC" }
+
+// Desc order by the column descending
+func (c userInt64Column) Desc() string { return string(c) + " DESC" }
+
+// Eq column equals to value
+func (c userInt64Column) Eq(value int64) *gorm.Condition { return gorm.Cond.Eq(string(c), value) }
+
+// Neq column doesn't equal to value
+func (c userInt64Column) Neq(value int64) *gorm.Condition { return gorm.Cond.Neq(string(c), value) }
+
+// Gt column is greater than value
+func (c userInt64Column) Gt(value int64) *gorm.Condition { return gorm.Cond.Gt(string(c), value) }
+
+// Gte column is greater than or equal to value
+func (c userInt64Column) Gte(value int64) *gorm.Condition { return gorm.Cond.Gte(string(c), value) }
+
+// Lt column is less than value
+func (c userInt64Column) Lt(value int64) *gorm.Condition { return gorm.Cond.Lt(string(c), value) }
+
+// Lte column is less than or equal to value
+func (c userInt64Column) Lte(value int64) *gorm.Condition { return gorm.Cond.Lte(string(c), value) }
+
+// Between column is between from and to
+func (c userInt64Column) Between(from, to int64) *gorm.Condition {
+	return gorm.Cond.Between(string(c), from, to)
+}
+
+// In column is one of values
+func (c userInt64Column) In(values ...int64) *gorm.Condition { return gorm.Cond.In(string(c), values) }
+
+// NotIn column isn't any of values
+func (c userInt64Column) NotIn(values ...int64) *gorm.Condition {
+	return gorm.Cond.NotIn(string(c), values)
+}
+
+// IsNull column is NULL
+func (c userInt64Column) IsNull() *gorm.Condition { return gorm.Cond.IsNull(string(c)) }
+
+// IsNotNull column isn't NULL
+func (c userInt64Column) IsNotNull() *gorm.Condition { return gorm.Cond.IsNotNull(string(c)) }
+
+// userIntColumn handle of User's int columns
+type userIntColumn string
+
+// Name return the column name
+func (c userIntColumn) Name() string { return string(c) }
+
+// Asc order by the column ascending
+func (c userIntColumn) Asc() string { return string(c) + " ASC" }
+
+// Desc order by the column descending
+func (c userIntColumn) Desc() string { return string(c) + " DESC" }
+
+// Eq column equals to value
+func (c userIntColumn) Eq(value int) *gorm.Condition { return gorm.Cond.Eq(string(c), value) }
+
+// Neq column doesn't equal to value
+func (c userIntColumn) Neq(value int) *gorm.Condition { return gorm.Cond.Neq(string(c), value) }
+
+// Gt column is greater than value
+func (c userIntColumn) Gt(value int) *gorm.Condition { return gorm.Cond.Gt(string(c), value) }
+
+// Gte column is greater than or equal to value
+func (c userIntColumn) Gte(value int) *gorm.Condition { return gorm.Cond.Gte(string(c), value) }
+
+// Lt column is less than value
+func (c userIntColumn) Lt(value int) *gorm.Condition { return gorm.Cond.Lt(string(c), value) }
+
+// Lte column is less than or equal to value
+func (c userIntColumn) Lte(value int) *gorm.Condition { return gorm.Cond.Lte(string(c), value) }
+
+// Between column is between from and to
+func (c userIntColumn) Between(from, to int) *gorm.Condition {
+	return gorm.Cond.Between(string(c), from, to)
+}
+
+// In column is one of values
+func (c userIntColumn) In(values ...int) *gorm.Condition { return gorm.Cond.In(string(c), values) }
+
+// NotIn column isn't any of values
+func (c userIntColumn) NotIn(values ...int) *gorm.Condition {
+	return gorm.Cond.NotIn(string(c), values)
+}
+
+// IsNull column is NULL
+func (c userIntColumn) IsNull() *gorm.Condition { return gorm.Cond.IsNull(string(c)) }
+
+// IsNotNull column isn't NULL
+func (c userIntColumn) IsNotNull() *gorm.Condition { return gorm.Cond.IsNotNull(string(c)) }
+
+// userRoleColumn handle of User's Role columns
+type userRoleColumn string
+
+// Name return the column name
+func (c userRoleColumn) Name() string { return string(c) }
+
+// Asc order by the column ascending
+func (c userRoleColumn) Asc() string { return string(c) + " ASC" }
+
+// Desc order by the column descending
+func (c userRoleColumn) Desc() string { return string(c) + " DESC" }
+
+// Eq column equals to value
+func (c userRoleColumn) Eq(value Role) *gorm.Condition { return gorm.Cond.Eq(string(c), value) }
+
+// Neq column doesn't equal to value
+func (c userRoleColumn) Neq(value Role) *gorm.Condition { return gorm.Cond.Neq(string(c), value) }
+
+// Gt column is greater than value
+func (c userRoleColumn) Gt(value Role) *gorm.Condition { return gorm.Cond.Gt(string(c), value) }
+
+// Gte column is greater than or equal to value
+func (c userRoleColumn) Gte(value Role) *gorm.Condition { return gorm.Cond.Gte(string(c), value) }
+
+// Lt column is less than value
+func (c userRoleColumn) Lt(value Role) *gorm.Condition { return gorm.Cond.Lt(string(c), value) }
+
+// Lte column is less than or equal to value
+func (c userRoleColumn) Lte(value Role) *gorm.Condition { return gorm.Cond.Lte(string(c), value) }
+
+// Between column is between from and to
+func (c userRoleColumn) Between(from, to Role) *gorm.Condition {
+	return gorm.Cond.Between(string(c), from, to)
+}
+
+// In column is one of values
+func (c userRoleColumn) In(values ...Role) *gorm.Condition { return gorm.Cond.In(string(c), values) }
+
+// NotIn column isn't any of values
+func (c userRoleColumn) NotIn(values ...Role) *gorm.Condition {
+	return gorm.Cond.NotIn(string(c), values)
+}
+
+// IsNull column is NULL
+func (c userRoleColumn) IsNull() *gorm.Condition { return gorm.Cond.IsNull(string(c)) }
+
+// IsNotNull column isn't NULL
+func (c userRoleColumn) IsNotNull() *gorm.Condition { return gorm.Cond.IsNotNull(string(c)) }
+
+// userSliceByteColumn handle of User's []byte columns
+type userSliceByteColumn string
+
+// Name return the column name
+func (c userSliceByteColumn) Name() string { return string(c) }
+
+// Asc order by the column ascending
+func (c userSliceByteColumn) Asc() string { return string(c) + " ASC" }
+
+// Desc order by the column descending
+func (c userSliceByteColumn) Desc() string { return string(c) + " DESC" }
+
+// Eq column equals to value
+func (c userSliceByteColumn) Eq(value []byte) *gorm.Condition { return gorm.Cond.Eq(string(c), value) }
+
+// Neq column doesn't equal to value
+func (c userSliceByteColumn) Neq(value []byte) *gorm.Condition {
+	return gorm.Cond.Neq(string(c), value)
+}
+
+// Gt column is greater than value
+func (c userSliceByteColumn) Gt(value []byte) *gorm.Condition { return gorm.Cond.Gt(string(c), value) }
+
+// Gte column is greater than or equal to value
+func (c userSliceByteColumn) Gte(value []byte) *gorm.Condition {
+	return gorm.Cond.Gte(string(c), value)
+}
+
+// Lt column is less than value
+func (c userSliceByteColumn) Lt(value []byte) *gorm.Condition { return gorm.Cond.Lt(string(c), value) }
+
+// Lte column is less than or equal to value
+func (c userSliceByteColumn) Lte(value []byte) *gorm.Condition {
+	return gorm.Cond.Lte(string(c), value)
+}
+
+// Between column is between from and to
+func (c userSliceByteColumn) Between(from, to []byte) *gorm.Condition {
+	return gorm.Cond.Between(string(c), from, to)
+}
+
+// In column is one of values
+func (c userSliceByteColumn) In(values ...[]byte) *gorm.Condition {
+	return gorm.Cond.In(string(c), values)
+}
+
+// NotIn column isn't any of values
+func (c userSliceByteColumn) NotIn(values ...[]byte) *gorm.Condition {
+	return gorm.Cond.NotIn(string(c), values)
+}
+
+// IsNull column is NULL
+func (c userSliceByteColumn) IsNull() *gorm.Condition { return gorm.Cond.IsNull(string(c)) }
+
+// IsNotNull column isn't NULL
+func (c userSliceByteColumn) IsNotNull() *gorm.Condition { return gorm.Cond.IsNotNull(string(c)) }
+
+// userSqlNullInt64Column handle of User's sql.NullInt64 columns
+type userSqlNullInt64Column string
+
+// Name return the column name
+func (c userSqlNullInt64Column) Name() string { return string(c) }
+
+// Asc order by the column ascending
+func (c userSqlNullInt64Column) Asc() string { return string(c) + " ASC" }
+
+// Desc order by the column descending
+func (c userSqlNullInt64Column) Desc() string { return string(c) + " DESC" }
+
+// Eq column equals to value
+func (c userSqlNullInt64Column) Eq(value sql.NullInt64) *gorm.Condition {
+	return gorm.Cond.Eq(string(c), value)
+}
+
+// Neq column doesn't equal to value
+func (c userSqlNullInt64Column) Neq(value sql.NullInt64) *gorm.Condition {
+	return gorm.Cond.Neq(string(c), value)
+}
+
+// Gt column is greater than value
+func (c userSqlNullInt64Column) Gt(value sql.NullInt64) *gorm.Condition {
+	return gorm.Cond.Gt(string(c), value)
+}
+
+// Gte column is greater than or equal to value
+func (c userSqlNullInt64Column) Gte(value sql.NullInt64) *gorm.Condition {
+	return gorm.Cond.Gte(string(c), value)
+}
+
+// Lt column is less than value
+func (c userSqlNullInt64Column) Lt(value sql.NullInt64) *gorm.Condition {
+	return gorm.Cond.Lt(string(c), value)
+}
+
+// Lte column is less than or equal to value
+func (c userSqlNullInt64Column) Lte(value sql.NullInt64) *gorm.Condition {
+	return gorm.Cond.Lte(string(c), value)
+}
+
+// Between column is between from and to
+func (c userSqlNullInt64Column) Between(from, to sql.NullInt64) *gorm.Condition {
+	return gorm.Cond.Between(string(c), from, to)
+}
+
+// In column is one of values
+func (c userSqlNullInt64Column) In(values ...sql.NullInt64) *gorm.Condition {
+	return gorm.Cond.In(string(c), values)
+}
+
+// NotIn column isn't any of values
+func (c userSqlNullInt64Column) NotIn(values ...sql.NullInt64) *gorm.Condition {
+	return gorm.Cond.NotIn(string(c), values)
+}
+
+// IsNull column is NULL
+func (c userSqlNullInt64Column) IsNull() *gorm.Condition { return gorm.Cond.IsNull(string(c)) }
+
+// IsNotNull column isn't NULL
+func (c userSqlNullInt64Column) IsNotNull() *gorm.Condition { return gorm.Cond.IsNotNull(string(c)) }
+
+// userStringColumn handle of User's string columns
+type userStringColumn string
+
+// Name return the column name
+func (c userStringColumn) Name() string { return string(c) }
+
+// Asc order by the column ascending
+func (c userStringColumn) Asc() string { return string(c) + " ASC" }
+
+// Desc order by the column descending
+func (c userStringColumn) Desc() string { return string(c) + " DESC" }
+
+// Eq column equals to value
+func (c userStringColumn) Eq(value string) *gorm.Condition { return gorm.Cond.Eq(string(c), value) }
+
+// Neq column doesn't equal to value
+func (c userStringColumn) Neq(value string) *gorm.Condition { return gorm.Cond.Neq(string(c), value) }
+
+// Gt column is greater than value
+func (c userStringColumn) Gt(value string) *gorm.Condition { return gorm.Cond.Gt(string(c), value) }
+
+// Gte column is greater than or equal to value
+func (c userStringColumn) Gte(value string) *gorm.Condition { return gorm.Cond.Gte(string(c), value) }
+
+// Lt column is less than value
+func (c userStringColumn) Lt(value string) *gorm.Condition { return gorm.Cond.Lt(string(c), value) }
+
+// Lte column is less than or equal to value
+func (c userStringColumn) Lte(value string) *gorm.Condition { return gorm.Cond.Lte(string(c), value) }
+
+// Between column is between from and to
+func (c userStringColumn) Between(from, to string) *gorm.Condition {
+	return gorm.Cond.Between(string(c), from, to)
+}
+
+// In column is one of values
+func (c userStringColumn) In(values ...string) *gorm.Condition {
+	return gorm.Cond.In(string(c), values)
+}
+
+// NotIn column isn't any of values
+func (c userStringColumn) NotIn(values ...string) *gorm.Condition {
+	return gorm.Cond.NotIn(string(c), values)
+}
+
+// IsNull column is NULL
+func (c userStringColumn) IsNull() *gorm.Condition { return gorm.Cond.IsNull(string(c)) }
+
+// IsNotNull column isn't NULL
+func (c userStringColumn) IsNotNull() *gorm.Condition { return gorm.Cond.IsNotNull(string(c)) }
+
+// Like column matches the pattern
+func (c userStringColumn) Like(pattern string) *gorm.Condition {
+	return gorm.Cond.Like(string(c), pattern)
+}
+
+// userTagsColumn handle of User's Tags columns
+type userTagsColumn string
+
+// Name return the column name
+func (c userTagsColumn) Name() string { return string(c) }
+
+// Asc order by the column ascending
+func (c userTagsColumn) Asc() string { return string(c) + " ASC" }
+
+// Desc order by the column descending
+func (c userTagsColumn) Desc() string { return string(c) + " DESC" }
+
+// Eq column equals to value
+func (c userTagsColumn) Eq(value Tags) *gorm.Condition { return gorm.Cond.Eq(string(c), value) }
+
+// Neq column doesn't equal to value
+func (c userTagsColumn) Neq(value Tags) *gorm.Condition { return gorm.Cond.Neq(string(c), value) }
+
+// Gt column is greater than value
+func (c userTagsColumn) Gt(value Tags) *gorm.Condition { return gorm.Cond.Gt(string(c), value) }
+
+// Gte column is greater than or equal to value
+func (c userTagsColumn) Gte(value Tags) *gorm.Condition { return gorm.Cond.Gte(string(c), value) }
+
+// Lt column is less than value
+func (c userTagsColumn) Lt(value Tags) *gorm.Condition { return gorm.Cond.Lt(string(c), value) }
+
+// Lte column is less than or equal to value
+func (c userTagsColumn) Lte(value Tags) *gorm.Condition { return gorm.Cond.Lte(string(c), value) }
+
+// Between column is between from and to
+func (c userTagsColumn) Between(from, to Tags) *gorm.Condition {
+	return gorm.Cond.Between(string(c), from, to)
+}
+
+// In column is one of values
+func (c userTagsColumn) In(values ...Tags) *gorm.Condition { return gorm.Cond.In(string(c), values) }
+
+// NotIn column isn't any of values
+func (c userTagsColumn) NotIn(values ...Tags) *gorm.Condition {
+	return gorm.Cond.NotIn(string(c), values)
+}
+
+// IsNull column is NULL
+func (c userTagsColumn) IsNull() *gorm.Condition { return gorm.Cond.IsNull(string(c)) }
+
+// IsNotNull column isn't NULL
+func (c userTagsColumn) IsNotNull() *gorm.Condition { return gorm.Cond.IsNotNull(string(c)) }
+
+// userTimeTimeColumn handle of User's time.Time columns
+type userTimeTimeColumn string
+
+// Name return the column name
+func (c userTimeTimeColumn) Name() string { return string(c) }
+
+// Asc order by the column ascending
+func (c userTimeTimeColumn) Asc() string { return string(c) + " ASC" }
+
+// Desc order by the column descending
+func (c userTimeTimeColumn) Desc() string { return string(c) + " DESC" }
+
+// Eq column equals to value
+func (c userTimeTimeColumn) Eq(value time.Time) *gorm.Condition {
+	return gorm.Cond.Eq(string(c), value)
+}
+
+// Neq column doesn't equal to value
+func (c userTimeTimeColumn) Neq(value time.Time) *gorm.Condition {
+	return gorm.Cond.Neq(string(c), value)
+}
+
+// Gt column is greater than value
+func (c userTimeTimeColumn) Gt(value time.Time) *gorm.Condition {
+	return gorm.Cond.Gt(string(c), value)
+}
+
+// Gte column is greater than or equal to value
+func (c userTimeTimeColumn) Gte(value time.Time) *gorm.Condition {
+	return gorm.Cond.Gte(string(c), value)
+}
+
+// Lt column is less than value
+func (c userTimeTimeColumn) Lt(value time.Time) *gorm.Condition {
+	return gorm.Cond.Lt(string(c), value)
+}
+
+// Lte column is less than or equal to value
+func (c userTimeTimeColumn) Lte(value time.Time) *gorm.Condition {
+	return gorm.Cond.Lte(string(c), value)
+}
+
+// Between column is between from and to
+func (c userTimeTimeColumn) Between(from, to time.Time) *gorm.Condition {
+	return gorm.Cond.Between(string(c), from, to)
+}
+
+// In column is one of values
+func (c userTimeTimeColumn) In(values ...time.Time) *gorm.Condition {
+	return gorm.Cond.In(string(c), values)
+}
+
+// NotIn column isn't any of values
+func (c userTimeTimeColumn) NotIn(values ...time.Time) *gorm.Condition {
+	return gorm.Cond.NotIn(string(c), values)
+}
+
+// IsNull column is NULL
+func (c userTimeTimeColumn) IsNull() *gorm.Condition { return gorm.Cond.IsNull(string(c)) }
+
+// IsNotNull column isn't NULL
+func (c userTimeTimeColumn) IsNotNull() *gorm.Condition { return gorm.Cond.IsNotNull(string(c)) }
+
+// UserQuery typed queries of User
+type UserQuery struct {
+	db gorm.Repository
+}
+
+// NewUserQuery create typed queries of User with db
+func NewUserQuery(db gorm.Repository) UserQuery {
+	return UserQuery{db: db.Model(&User{})}
+}
+
+// DB return the underlying repository
+func (q UserQuery) DB() gorm.Repository { return q.db }
+
+// Where filter records matching all conditions
+func (q UserQuery) Where(conditions ...*gorm.Condition) UserQuery {
+	db := q.db
+	for _, condition := range conditions {
+		db = db.Where(condition)
+	}
+	return UserQuery{db: db}
+}
+
+// Or filter records matching before conditions or this one
+func (q UserQuery) Or(condition *gorm.Condition) UserQuery {
+	return UserQuery{db: q.db.Or(condition)}
+}
+
+// Not filter records not matching the condition
+func (q UserQuery) Not(condition *gorm.Condition) UserQuery {
+	return UserQuery{db: q.db.Not(condition)}
+}
+
+// Order order records, e.g: `UserCols.ID.Desc()`
+func (q UserQuery) Order(orders ...string) UserQuery {
+	db := q.db
+	for _, order := range orders {
+		db = db.Order(order)
+	}
+	return UserQuery{db: db}
+}
+
+// Limit limit the number of records
+func (q UserQuery) Limit(limit int) UserQuery {
+	return UserQuery{db: q.db.Limit(limit)}
+}
+
+// Offset skip records before
+func (q UserQuery) Offset(offset int) UserQuery {
+	return UserQuery{db: q.db.Offset(offset)}
+}
+
+// PreloadEmails preload Emails of found records
+func (q UserQuery) PreloadEmails(conditions ...interface{}) UserQuery {
+	return UserQuery{db: q.db.Preload("Emails", conditions...)}
+}
+
+// PreloadCompany preload Company of found records
+func (q UserQuery) PreloadCompany(conditions ...interface{}) UserQuery {
+	return UserQuery{db: q.db.Preload("Company", conditions...)}
+}
+
+// Find find records
+func (q UserQuery) Find() ([]User, error) {
+	var results []User
+	err := q.db.Find(&results).Error()
+	return results, err
+}
+
+// First find the first record ordered by primary key
+func (q UserQuery) First() (User, error) {
+	var result User
+	err := q.db.First(&result).Error()
+	return result, err
+}
+
+// Count count matched records
+func (q UserQuery) Count() (int64, error) {
+	var count int64
+	err := q.db.Count(&count).Error()
+	return count, err
+}
+
+// Delete delete matched records
+func (q UserQuery) Delete() error {
+	return q.db.Delete(&User{}).Error()
+}
+
+// EmailCols typed handles of Email's columns
+var EmailCols = struct {
+	Id     emailInt64Column
+	UserId emailInt64Column
+	Email  emailStringColumn
+}{
+	Id:     "id",
+	UserId: "user_id",
+	Email:  "email",
+}
+
+// emailInt64Column handle of Email's int64 columns
+type emailInt64Column string
+
+// Name return the column name
+func (c emailInt64Column) Name() string { return string(c) }
+
+// Asc order by the column ascending
+func (c emailInt64Column) Asc() string { return string(c) + " ASC" }
+
+// Desc order by the column descending
+func (c emailInt64Column) Desc() string { return string(c) + " DESC" }
+
+// Eq column equals to value
+func (c emailInt64Column) Eq(value int64) *gorm.Condition { return gorm.Cond.Eq(string(c), value) }
+
+// Neq column doesn't equal to value
+func (c emailInt64Column) Neq(value int64) *gorm.Condition { return gorm.Cond.Neq(string(c), value) }
+
+// Gt column is greater than value
+func (c emailInt64Column) Gt(value int64) *gorm.Condition { return gorm.Cond.Gt(string(c), value) }
+
+// Gte column is greater than or equal to value
+func (c emailInt64Column) Gte(value int64) *gorm.Condition { return gorm.Cond.Gte(string(c), value) }
+
+// Lt column is less than value
+func (c emailInt64Column) Lt(value int64) *gorm.Condition { return gorm.Cond.Lt(string(c), value) }
+
+// Lte column is less than or equal to value
+func (c emailInt64Column) Lte(value int64) *gorm.Condition { return gorm.Cond.Lte(string(c), value) }
+
+// Between column is between from and to
+func (c emailInt64Column) Between(from, to int64) *gorm.Condition {
+	return gorm.Cond.Between(string(c), from, to)
+}
+
+// In column is one of values
+func (c emailInt64Column) In(values ...int64) *gorm.Condition { return gorm.Cond.In(string(c), values) }
+
+// NotIn column isn't any of values
+func (c emailInt64Column) NotIn(values ...int64) *gorm.Condition {
+	return gorm.Cond.NotIn(string(c), values)
+}
+
+// IsNull column is NULL
+func (c emailInt64Column) IsNull() *gorm.Condition { return gorm.Cond.IsNull(string(c)) }
+
+// IsNotNull column isn't NULL
+func (c emailInt64Column) IsNotNull() *gorm.Condition { return gorm.Cond.IsNotNull(string(c)) }
+
+// emailStringColumn handle of Email's string columns
+type emailStringColumn string
+
+// Name return the column name
+func (c emailStringColumn) Name() string { return string(c) }
+
+// Asc order by the column ascending
+func (c emailStringColumn) Asc() string { return string(c) + " ASC" }
+
+// Desc order by the column descending
+func (c emailStringColumn) Desc() string { return string(c) + " DESC" }
+
+// Eq column equals to value
+func (c emailStringColumn) Eq(value string) *gorm.Condition { return gorm.Cond.Eq(string(c), value) }
+
+// Neq column doesn't equal to value
+func (c emailStringColumn) Neq(value string) *gorm.Condition { return gorm.Cond.Neq(string(c), value) }
+
+// Gt column is greater than value
+func (c emailStringColumn) Gt(value string) *gorm.Condition { return gorm.Cond.Gt(string(c), value) }
+
+// Gte column is greater than or equal to value
+func (c emailStringColumn) Gte(value string) *gorm.Condition { return gorm.Cond.Gte(string(c), value) }
+
+// Lt column is less than value
+func (c emailStringColumn) Lt(value string) *gorm.Condition { return gorm.Cond.Lt(string(c), value) }
+
+// Lte column is less than or equal to value
+func (c emailStringColumn) Lte(value string) *gorm.Condition { return gorm.Cond.Lte(string(c), value) }
+
+// Between column is between from and to
+func (c emailStringColumn) Between(from, to string) *gorm.Condition {
+	return gorm.Cond.Between(string(c), from, to)
+}
+
+// In column is one of values
+func (c emailStringColumn) In(values ...string) *gorm.Condition {
+	return gorm.Cond.In(string(c), values)
+}
+
+// NotIn column isn't any of values
+func (c emailStringColumn) NotIn(values ...string) *gorm.Condition {
+	return gorm.Cond.NotIn(string(c), values)
+}
+
+// IsNull column is NULL
+func (c emailStringColumn) IsNull() *gorm.Condition { return gorm.Cond.IsNull(string(c)) }
+
+// IsNotNull column isn't NULL
+func (c emailStringColumn) IsNotNull() *gorm.Condition { return gorm.Cond.IsNotNull(string(c)) }
+
+// Like column matches the pattern
+func (c emailStringColumn) Like(pattern string) *gorm.Condition {
+	return gorm.Cond.Like(string(c), pattern)
+}
+
+// EmailQuery typed queries of Email
+type EmailQuery struct {
+	db gorm.Repository
+}
+
+// NewEmailQuery create typed queries of Email with db
+func NewEmailQuery(db gorm.Repository) EmailQuery {
+	return EmailQuery{db: db.Model(&Email{})}
+}
+
+// DB return the underlying repository
+func (q EmailQuery) DB() gorm.Repository { return q.db }
+
+// Where filter records matching all conditions
+func (q EmailQuery) Where(conditions ...*gorm.Condition) EmailQuery {
+	db := q.db
+	for _, condition := range conditions {
+		db = db.Where(condition)
+	}
+	return EmailQuery{db: db}
+}
+
+// Or filter records matching before conditions or this one
+func (q EmailQuery) Or(condition *gorm.Condition) EmailQuery {
+	return EmailQuery{db: q.db.Or(condition)}
+}
+
+// Not filter records not matching the condition
+func (q EmailQuery) Not(condition *gorm.Condition) EmailQuery {
+	return EmailQuery{db: q.db.Not(condition)}
+}
+
+// Order order records, e.g: `EmailCols.Id.Desc()`
+func (q EmailQuery) Order(orders ...string) EmailQuery {
+	db := q.db
+	for _, order := range orders {
+		db = db.Order(order)
+	}
+	return EmailQuery{db: db}
+}
+
+// Limit limit the number of records
+func (q EmailQuery) Limit(limit int) EmailQuery {
+	return EmailQuery{db: q.db.Limit(limit)}
+}
+
+// Offset skip records before
+func (q EmailQuery) Offset(offset int) EmailQuery {
+	return EmailQuery{db: q.db.Offset(offset)}
+}
+
+// Find find records
+func (q EmailQuery) Find() ([]Email, error) {
+	var results []Email
+	err := q.db.Find(&results).Error()
+	return results, err
+}
+
+// First find the first record ordered by primary key
+func (q EmailQuery) First() (Email, error) {
+	var result Email
+	err := q.db.First(&result).Error()
+	return result, err
+}
+
+// Count count matched records
+func (q EmailQuery) Count() (int64, error) {
+	var count int64
+	err := q.db.Count(&count).Error()
+	return count, err
+}
+
+// Delete delete matched records
+func (q EmailQuery) Delete() error {
+	return q.db.Delete(&Email{}).Error()
+}
@@ -0,0 +1,101 @@
+package gorm
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Commenter returns tags that will be appended to every generated statement as a sqlcommenter comment, e.g:
+//     gorm.Commenter = gorm.CallerCommenter
+//     // SELECT * FROM "users" /* caller='app%2Fusers.go%3A42' */
+// Tags from the context (refer `WithComment`) and the `gorm:comment` setting take precedence over it
+var Commenter func(scope *Scope) map[string]string
+
+// CallerCommenter builtin Commenter, tags statements with the file and line number that issued them
+func CallerCommenter(scope *Scope) map[string]string {
+	return map[string]string{"caller": fileWithLineNum()}
+}
+
+type commentContextKey struct{}
+
+// WithComment return a copy of ctx carrying the comment tag, it will be appended to statements run with `db.WithContext(ctx)`
+//     ctx = gorm.WithComment(ctx, "request_id", requestID)
+//     db.WithContext(ctx).Find(&users)
+func WithComment(ctx context.Context, key, value string) context.Context {
+	tags := map[string]string{}
+	if parent, ok := ctx.Value(commentContextKey{}).(map[string]string); ok {
+		for k, v := range parent {
+			tags[k] = v
+		}
+	}
+	tags[key] = value
+	return context.WithValue(ctx, commentContextKey{}, tags)
+}
+
+// Context return the context set with `WithContext`, or `context.Background()`
+func (scope *Scope) Context() context.Context {
+	return contextOf(scope.db)
+}
+
+// comment build sqlcommenter comment for current statement, return blank string if there are no tags
+func (scope *Scope) comment() string {
+	tags := map[string]string{}
+
+	if Commenter != nil {
+		for key, value := range Commenter(scope) {
+			tags[key] = value
+		}
+	}
+
+	if values, ok := scope.Context().Value(commentContextKey{}).(map[string]string); ok {
+		for key, value := range values {
+			tags[key] = value
+		}
+	}
+
+	if values, ok := scope.Get("gorm:comment"); ok {
+		switch values := values.(type) {
+		case map[string]string:
+			for key, value := range values {
+				tags[key] = value
+			}
+		case map[string]interface{}:
+			for key, value := range values {
+				tags[key] = fmt.Sprint(value)
+			}
+		}
+	}
+
+	if len(tags) == 0 {
+		return ""
+	}
+
+	var pairs []string
+	for key, value := range tags {
+		pairs = append(pairs, fmt.Sprintf("%v='%v'", escapeCommentValue(key), escapeCommentValue(value)))
+	}
+	sort.Strings(pairs)
+	return "/* " + strings.Join(pairs, ",") + " */"
+}
+
+// addComment append sqlcommenter comment to current SQL, it is placed after the statement but before trailing `;`,
+// which is accepted by all supported dialects
+func (scope *Scope) addComment() *Scope {
+	if comment := scope.comment(); comment != "" {
+		sql := strings.TrimRight(scope.SQL, " \t\r\n")
+		if strings.HasSuffix(sql, ";") {
+			scope.SQL = strings.TrimSuffix(sql, ";") + " " + comment + ";"
+		} else {
+			scope.SQL = sql + " " + comment
+		}
+	}
+	return scope
+}
+
+// escapeCommentValue url encode the value as sqlcommenter requires, quotes and `*/` are encoded too, so values can't break out of the comment
+func escapeCommentValue(value string) string {
+	return url.PathEscape(value)
+}
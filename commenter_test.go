@@ -0,0 +1,56 @@
+package gorm_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zhinanxing/gorm/v3"
+)
+
+type sqlRecorder struct {
+	sqls []string
+}
+
+func (recorder *sqlRecorder) Print(values ...interface{}) {
+	if len(values) > 3 && values[0] == "sql" {
+		recorder.sqls = append(recorder.sqls, values[3].(string))
+	}
+}
+
+func (recorder *sqlRecorder) last() string {
+	if len(recorder.sqls) == 0 {
+		return ""
+	}
+	return recorder.sqls[len(recorder.sqls)-1]
+}
+
+func TestQueryComment(t *testing.T) {
+	recorder := &sqlRecorder{}
+	db := DB.New().LogMode(true).SetLogger(recorder)
+
+	var users []User
+	db.Set("gorm:comment", map[string]string{"service": "billing", "route": "/users/*/"}).Where("name = ?", "CommentUser").Find(&users)
+	if sql := recorder.last(); !strings.HasSuffix(sql, "/* route='%2Fusers%2F%2A%2F',service='billing' */") {
+		t.Errorf("Should append sqlcommenter comment to query, but got %v", sql)
+	}
+
+	ctx := gorm.WithComment(context.Background(), "request_id", "it's-42")
+	db.WithContext(ctx).Set("gorm:comment", map[string]string{"service": "billing"}).Create(&User{Name: "CommentUser"})
+	if sql := recorder.last(); !strings.HasPrefix(sql, "INSERT") || !strings.Contains(sql, "/* request_id='it%27s-42',service='billing' */") {
+		t.Errorf("Should append comment from context to insert, but got %v", sql)
+	}
+
+	gorm.Commenter = gorm.CallerCommenter
+	defer func() { gorm.Commenter = nil }()
+	db.Model(&User{}).Where("name = ?", "CommentUser").Update("age", 18)
+	if sql := recorder.last(); !strings.Contains(sql, "caller='") || !strings.Contains(sql, ".go:") {
+		t.Errorf("Should append caller to update, but got %v", sql)
+	}
+
+	gorm.Commenter = nil
+	db.Where("name = ?", "CommentUser").Find(&users)
+	if sql := recorder.last(); strings.Contains(sql, "/*") {
+		t.Errorf("Should not append comment without tags, but got %v", sql)
+	}
+}
@@ -0,0 +1,205 @@
+package gorm
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+var (
+	// conditionOperators operators could be used in map keys and `Cond`, e.g: `map[string]interface{}{"age >": 18}`
+	conditionOperators = map[string]bool{
+		"=": true, "<>": true, "!=": true, ">": true, ">=": true, "<": true, "<=": true,
+		"LIKE": true, "NOT LIKE": true, "IN": true, "NOT IN": true, "IS": true, "IS NOT": true,
+		"BETWEEN": true, "NOT BETWEEN": true,
+	}
+	conditionColumnRegexp = regexp.MustCompile("^[a-zA-Z_][\\w]*(\\.[a-zA-Z_][\\w]*)*$") // only match string like `user_id`, `users.user_id`
+)
+
+// Condition is a condition built with `Cond`, columns are quoted and values are added as vars
+type Condition struct {
+	column     string
+	operator   string
+	value      interface{}
+	conditions []*Condition
+}
+
+// ConditionBuilder build conditions could be used in `Where`, `Or` and `Not`, refer `Cond`
+type ConditionBuilder struct{}
+
+// Cond build conditions without concatenating strings
+//     db.Where(gorm.Cond.And(gorm.Cond.Eq("role", "admin"), gorm.Cond.Or(gorm.Cond.Gt("age", 18), gorm.Cond.IsNull("age")))).Find(&users)
+//     // SELECT * FROM "users" WHERE (("users"."role" = 'admin') AND (("users"."age" > 18) OR ("users"."age" IS NULL)))
+var Cond = ConditionBuilder{}
+
+// Eq column equals to value, or is NULL if value is nil
+func (ConditionBuilder) Eq(column string, value interface{}) *Condition {
+	return &Condition{column: column, operator: "=", value: value}
+}
+
+// Neq column doesn't equal to value, or isn't NULL if value is nil
+func (ConditionBuilder) Neq(column string, value interface{}) *Condition {
+	return &Condition{column: column, operator: "<>", value: value}
+}
+
+// Gt column is greater than value
+func (ConditionBuilder) Gt(column string, value interface{}) *Condition {
+	return &Condition{column: column, operator: ">", value: value}
+}
+
+// Gte column is greater than or equal to value
+func (ConditionBuilder) Gte(column string, value interface{}) *Condition {
+	return &Condition{column: column, operator: ">=", value: value}
+}
+
+// Lt column is less than value
+func (ConditionBuilder) Lt(column string, value interface{}) *Condition {
+	return &Condition{column: column, operator: "<", value: value}
+}
+
+// Lte column is less than or equal to value
+func (ConditionBuilder) Lte(column string, value interface{}) *Condition {
+	return &Condition{column: column, operator: "<=", value: value}
+}
+
+// Like column matches the pattern
+func (ConditionBuilder) Like(column string, pattern interface{}) *Condition {
+	return &Condition{column: column, operator: "LIKE", value: pattern}
+}
+
+// In column is one of values, values could be a slice or a sub query
+func (ConditionBuilder) In(column string, values interface{}) *Condition {
+	return &Condition{column: column, operator: "IN", value: values}
+}
+
+// NotIn column isn't any of values, values could be a slice or a sub query
+func (ConditionBuilder) NotIn(column string, values interface{}) *Condition {
+	return &Condition{column: column, operator: "NOT IN", value: values}
+}
+
+// Between column is between from and to
+func (ConditionBuilder) Between(column string, from, to interface{}) *Condition {
+	return &Condition{column: column, operator: "BETWEEN", value: []interface{}{from, to}}
+}
+
+// IsNull column is NULL
+func (ConditionBuilder) IsNull(column string) *Condition {
+	return &Condition{column: column, operator: "IS"}
+}
+
+// IsNotNull column isn't NULL
+func (ConditionBuilder) IsNotNull(column string) *Condition {
+	return &Condition{column: column, operator: "IS NOT"}
+}
+
+// And all of conditions are matched
+func (ConditionBuilder) And(conditions ...*Condition) *Condition {
+	return &Condition{operator: "AND", conditions: conditions}
+}
+
+// Or any of conditions is matched
+func (ConditionBuilder) Or(conditions ...*Condition) *Condition {
+	return &Condition{operator: "OR", conditions: conditions}
+}
+
+// Not condition isn't matched
+func (ConditionBuilder) Not(condition *Condition) *Condition {
+	return &Condition{operator: "NOT", conditions: []*Condition{condition}}
+}
+
+// conditionSQL build sql of the condition
+func (scope *Scope) conditionSQL(condition *Condition) (string, error) {
+	if condition == nil {
+		return "", fmt.Errorf("invalid query condition: %v", condition)
+	}
+
+	switch condition.operator {
+	case "AND", "OR":
+		if len(condition.conditions) == 0 {
+			// no conditions in AND matches all, in OR matches nothing
+			if condition.operator == "AND" {
+				return "(1 = 1)", nil
+			}
+			return "(1 <> 1)", nil
+		}
+
+		var sqls []string
+		for _, c := range condition.conditions {
+			sql, err := scope.conditionSQL(c)
+			if err != nil {
+				return "", err
+			}
+			sqls = append(sqls, sql)
+		}
+		return "(" + strings.Join(sqls, " "+condition.operator+" ") + ")", nil
+	case "NOT":
+		sql, err := scope.conditionSQL(condition.conditions[0])
+		if err != nil {
+			return "", err
+		}
+		return "(NOT " + sql + ")", nil
+	}
+
+	return scope.operatorConditionSQL(condition.column, condition.operator, condition.value)
+}
+
+// operatorConditionSQL build sql compares the column with value using the operator, operators are limited to `conditionOperators`
+func (scope *Scope) operatorConditionSQL(column, operator string, value interface{}) (string, error) {
+	operator = strings.ToUpper(strings.Join(strings.Fields(operator), " "))
+	if !conditionOperators[operator] {
+		return "", fmt.Errorf("invalid query condition operator: %v", operator)
+	}
+
+	if !conditionColumnRegexp.MatchString(column) {
+		return "", fmt.Errorf("invalid query condition column: %v", column)
+	}
+
+	quotedColumn := scope.Quote(column)
+	if !strings.Contains(column, ".") {
+		quotedColumn = fmt.Sprintf("%v.%v", scope.QuotedTableName(), quotedColumn)
+	}
+
+	if value == nil {
+		switch operator {
+		case "=", "IS":
+			return fmt.Sprintf("(%v IS NULL)", quotedColumn), nil
+		case "<>", "!=", "IS NOT":
+			return fmt.Sprintf("(%v IS NOT NULL)", quotedColumn), nil
+		}
+		return "", fmt.Errorf("invalid query condition: %v %v NULL", column, operator)
+	}
+
+	reflectValue := reflect.ValueOf(value)
+	isSlice := reflectValue.Kind() == reflect.Slice && reflectValue.Type().Elem().Kind() != reflect.Uint8
+
+	switch operator {
+	case "IN", "NOT IN":
+		if expr, ok := value.(*Expression); ok {
+			// sub query, e.g: `db.Table("orders").Select("user_id").SubQuery()`
+			return fmt.Sprintf("(%v %v %v)", quotedColumn, operator, scope.AddToVars(expr)), nil
+		}
+
+		if !isSlice {
+			return fmt.Sprintf("(%v %v (%v))", quotedColumn, operator, scope.AddToVars(value)), nil
+		}
+
+		if reflectValue.Len() == 0 {
+			return fmt.Sprintf("(%v %v (NULL))", quotedColumn, operator), nil
+		}
+
+		var marks []string
+		for i := 0; i < reflectValue.Len(); i++ {
+			marks = append(marks, scope.AddToVars(reflectValue.Index(i).Interface()))
+		}
+		return fmt.Sprintf("(%v %v (%v))", quotedColumn, operator, strings.Join(marks, ",")), nil
+	case "BETWEEN", "NOT BETWEEN":
+		if !isSlice || reflectValue.Len() != 2 {
+			return "", fmt.Errorf("invalid query condition: %v %v should have 2 values", column, operator)
+		}
+		return fmt.Sprintf("(%v %v %v AND %v)", quotedColumn, operator,
+			scope.AddToVars(reflectValue.Index(0).Interface()), scope.AddToVars(reflectValue.Index(1).Interface())), nil
+	}
+
+	return fmt.Sprintf("(%v %v %v)", quotedColumn, operator, scope.AddToVars(value)), nil
+}
@@ -0,0 +1,63 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/zhinanxing/gorm/v3"
+)
+
+func TestOperatorConditions(t *testing.T) {
+	users := []User{{Name: "CondUser1", Age: 10}, {Name: "CondUser2", Age: 20}, {Name: "CondUser3", Age: 30}, {Name: "CondOther", Age: 40}}
+	for i := range users {
+		DB.Save(&users[i])
+	}
+
+	var names []string
+	DB.Model(&User{}).Where(map[string]interface{}{"name LIKE": "CondUser%", "age >": 10}).Order("age").Pluck("name", &names)
+	if len(names) != 2 || names[0] != "CondUser2" || names[1] != "CondUser3" {
+		t.Errorf("Should find users with operator map conditions, but got %v", names)
+	}
+
+	names = nil
+	DB.Model(&User{}).Where(map[string]interface{}{"id IN": []int64{users[0].Id, users[3].Id}, "birthday IS": nil}).Order("age").Pluck("name", &names)
+	if len(names) != 2 || names[0] != "CondUser1" || names[1] != "CondOther" {
+		t.Errorf("Should find users with IN and IS conditions, but got %v", names)
+	}
+
+	names = nil
+	DB.Model(&User{}).Where("name LIKE ?", "Cond%").Not(map[string]interface{}{"age BETWEEN": []int{15, 35}}).Order("age").Pluck("name", &names)
+	if len(names) != 2 || names[0] != "CondUser1" || names[1] != "CondOther" {
+		t.Errorf("Should find users with NOT BETWEEN conditions, but got %v", names)
+	}
+
+	if err := DB.Model(&User{}).Where(map[string]interface{}{"age; DROP TABLE users; --": 1}).Find(&[]User{}).Error(); err == nil {
+		t.Errorf("Should return error for invalid operator")
+	}
+
+	names = nil
+	cond := gorm.Cond.And(
+		gorm.Cond.Like("name", "Cond%"),
+		gorm.Cond.Or(gorm.Cond.Between("age", 15, 25), gorm.Cond.Gte("age", 40)),
+		gorm.Cond.NotIn("id", []int64{users[3].Id}),
+	)
+	DB.Model(&User{}).Where(cond).Order("age").Pluck("name", &names)
+	if len(names) != 1 || names[0] != "CondUser2" {
+		t.Errorf("Should find users with condition builder, but got %v", names)
+	}
+
+	names = nil
+	DB.Model(&User{}).Where(gorm.Cond.In("users.name", []string{"CondUser1", "CondUser3"})).Or(gorm.Cond.Eq("name", "CondOther")).Order("age").Pluck("name", &names)
+	if len(names) != 3 || names[0] != "CondUser1" || names[2] != "CondOther" {
+		t.Errorf("Should find users with condition builder in Or, but got %v", names)
+	}
+
+	names = nil
+	DB.Model(&User{}).Where("name LIKE ?", "CondUser%").Not(gorm.Cond.Lt("age", 20)).Order("age").Pluck("name", &names)
+	if len(names) != 2 || names[0] != "CondUser2" || names[1] != "CondUser3" {
+		t.Errorf("Should find users with condition builder in Not, but got %v", names)
+	}
+
+	if err := DB.Model(&User{}).Where(gorm.Cond.Eq("name = 'x' OR 1", 1)).Find(&[]User{}).Error(); err == nil {
+		t.Errorf("Should return error for invalid column")
+	}
+}
@@ -0,0 +1,45 @@
+package gorm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// withSQL render common table expressions added with `With` and `WithRecursive`, vars of them are added before the main query's
+func (scope *Scope) withSQL() string {
+	if len(scope.Search.ctes) == 0 {
+		return ""
+	}
+
+	var (
+		recursive bool
+		ctes      []string
+	)
+
+	for _, cte := range scope.Search.ctes {
+		name := cte.name
+		if !strings.Contains(name, "(") {
+			name = scope.Quote(name)
+		}
+
+		var sql string
+		switch query := cte.query.(type) {
+		case *Expression:
+			sql = scope.AddToVars(query)
+		case Repository:
+			sql = scope.AddToVars(query.QueryExpr())
+		default:
+			scope.Err(fmt.Errorf("unsupported common table expression %v, should be a Repository or *Expression", cte.name))
+			continue
+		}
+
+		recursive = recursive || cte.recursive
+		ctes = append(ctes, fmt.Sprintf("%v AS (%v)", name, sql))
+	}
+
+	// mssql doesn't accept the RECURSIVE keyword
+	if recursive && scope.Dialect().GetName() != "mssql" {
+		return "WITH RECURSIVE " + strings.Join(ctes, ", ") + " "
+	}
+	return "WITH " + strings.Join(ctes, ", ") + " "
+}
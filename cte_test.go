@@ -0,0 +1,75 @@
+package gorm_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zhinanxing/gorm/v3"
+)
+
+func TestWith(t *testing.T) {
+	if !DialectSupports("cte") {
+		t.Skip("common table expressions are not supported by the server")
+	}
+
+	for i := 0; i < 5; i++ {
+		DB.Save(&User{Name: "WithUser", Age: int64(i * 10)})
+	}
+
+	adults := DB.Model(&User{}).Select("id, name, age").Where("name = ? AND age >= ?", "WithUser", 20)
+
+	var users []User
+	if err := DB.With("adults", adults).Table("adults").Where("age < ?", 40).Order("age").Find(&users).Error(); err != nil {
+		t.Fatalf("No error should happen when query with common table expression, but got %v", err)
+	}
+
+	if len(users) != 2 || users[0].Age != 20 || users[1].Age != 30 {
+		t.Errorf("Should find users from common table expression, but got %#v", users)
+	}
+
+	var count int
+	DB.With("adults", adults).Table("adults").Count(&count)
+	if count != 3 {
+		t.Errorf("Should count users from common table expression, but got %v", count)
+	}
+
+	recorder := &sqlRecorder{}
+	db := DB.New()
+	db.SetLogger(recorder)
+
+	count = 0
+	db.LogMode(true).With("adults", adults).Table("adults").Where("age < ?", 40).Group("age").Count(&count)
+	if count != 2 {
+		t.Errorf("Should count grouped users from common table expression, but got %v", count)
+	}
+
+	if sql := recorder.last(); !strings.HasPrefix(strings.TrimSpace(sql), "WITH") {
+		t.Errorf("Common table expression should be hoisted outside of the count sub query, but got %v", sql)
+	}
+
+	var names []string
+	db.LogMode(true).Model(&User{}).With("adults", adults).Joins("JOIN adults ON adults.id = users.id").Where("users.age > ?", 20).Pluck("users.name", &names)
+	if len(names) != 2 {
+		t.Errorf("Should join common table expression, but got %v", names)
+	}
+
+	if sql := recorder.last(); !strings.HasPrefix(strings.TrimSpace(sql), "WITH") {
+		t.Errorf("Common table expression should be rendered ahead of the select, but got %v", sql)
+	}
+}
+
+func TestWithRecursive(t *testing.T) {
+	if !DialectSupports("recursive_cte") {
+		t.Skip("recursive common table expressions are not supported by the server")
+	}
+
+	var nums []int
+	if err := DB.WithRecursive("nums(n)", gorm.Expr("SELECT ? UNION ALL SELECT n + 1 FROM nums WHERE n < ?", 1, 5)).
+		Table("nums").Where("n > ?", 2).Pluck("n", &nums).Error(); err != nil {
+		t.Fatalf("No error should happen when query with recursive common table expression, but got %v", err)
+	}
+
+	if len(nums) != 3 || nums[0] != 3 || nums[2] != 5 {
+		t.Errorf("Should generate numbers with recursive common table expression, but got %v", nums)
+	}
+}
@@ -54,6 +54,20 @@ type Dialect interface {
 
 var dialectsMap = map[string]Dialect{}
 
+// featureSupporter implemented by dialects whose features depend on the server version, e.g: mysql
+type featureSupporter interface {
+	SupportsFeature(feature string) bool
+}
+
+// dialectSupports check if current dialect supports the feature, asks the dialect if it implements `featureSupporter`,
+// otherwise look up dialects that support it
+func (scope *Scope) dialectSupports(feature string, dialects map[string]bool) bool {
+	if supporter, ok := scope.Dialect().(featureSupporter); ok {
+		return supporter.SupportsFeature(feature)
+	}
+	return dialects[scope.Dialect().GetName()]
+}
+
 func newDialect(name string, db SQLCommon) Dialect {
 	if value, ok := dialectsMap[name]; ok {
 		dialect := reflect.New(reflect.TypeOf(value).Elem()).Interface().(Dialect)
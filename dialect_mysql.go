@@ -2,11 +2,13 @@ package gorm
 
 import (
 	"crypto/sha1"
+	"database/sql"
 	"fmt"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode/utf8"
 )
@@ -189,3 +191,71 @@ func (s mysql) BuildKeyName(kind, tableName string, fields ...string) string {
 func (mysql) DefaultValueStr() string {
 	return "VALUES()"
 }
+
+// mysqlFeatureVersions minimal MySQL and MariaDB versions supporting the feature
+var mysqlFeatureVersions = map[string]struct{ mysql, mariadb string }{
+	"explain_json":     {"5.6.5", "10.1.2"},
+	"cte":              {"8.0.1", "10.2.1"},
+	"recursive_cte":    {"8.0.1", "10.2.2"},
+	"window_function":  {"8.0.2", "10.2.0"},
+	"intersect_except": {"8.0.31", "10.3.0"},
+}
+
+// mysqlServerVersions versions of connected servers, cached for every *sql.DB
+var mysqlServerVersions sync.Map
+
+// ServerVersion return the version of connected server, e.g: `5.7.31-log`, `10.5.8-MariaDB`
+func (s mysql) ServerVersion() (version string) {
+	if value, ok := mysqlServerVersions.Load(s.db); ok {
+		return value.(string)
+	}
+
+	if s.db == nil {
+		return ""
+	}
+
+	s.db.QueryRow("SELECT VERSION()").Scan(&version)
+	// don't cache for transactions, they are short-lived
+	if _, ok := s.db.(*sql.DB); ok && version != "" {
+		mysqlServerVersions.Store(s.db, version)
+	}
+	return version
+}
+
+// SupportsFeature check if connected server supports the feature, which depends on its version, e.g: `window_function` since MySQL 8.0
+func (s mysql) SupportsFeature(feature string) bool {
+	minimal, ok := mysqlFeatureVersions[feature]
+	if !ok {
+		return true
+	}
+
+	version := s.ServerVersion()
+	if strings.Contains(version, "MariaDB") {
+		return compareVersion(strings.TrimPrefix(version, "5.5.5-"), minimal.mariadb) >= 0
+	}
+	return version != "" && compareVersion(version, minimal.mysql) >= 0
+}
+
+// compareVersion compare numeric parts of versions like `5.7.31-log` and `8.0.2`
+func compareVersion(version, other string) int {
+	parse := func(version string) (parts [3]int) {
+		if idx := strings.IndexFunc(version, func(r rune) bool { return r != '.' && (r < '0' || r > '9') }); idx >= 0 {
+			version = version[:idx]
+		}
+		for idx, part := range strings.SplitN(version, ".", 3) {
+			parts[idx], _ = strconv.Atoi(part)
+		}
+		return
+	}
+
+	a, b := parse(version), parse(other)
+	for idx := range a {
+		if a[idx] != b[idx] {
+			if a[idx] < b[idx] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
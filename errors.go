@@ -16,6 +16,8 @@ var (
 	ErrCantStartTransaction = errors.New("can't start transaction")
 	// ErrUnaddressable unaddressable value
 	ErrUnaddressable = errors.New("using unaddressable value")
+	// ErrInvalidCursor invalid cursor when you are trying to `Paginate` with a cursor that isn't returned by it
+	ErrInvalidCursor = errors.New("invalid cursor")
 )
 
 // Errors contains all happened errors
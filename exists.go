@@ -0,0 +1,69 @@
+package gorm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// exists check if there are any records match current conditions, only the first one is selected
+func (scope *Scope) exists() (bool, error) {
+	scope.Search.ignoreOrderQuery = true
+
+	// mssql requires ORDER BY with OFFSET ... FETCH, use TOP instead
+	selectQuery := "1"
+	if scope.Dialect().GetName() == "mssql" && scope.Search.offset == nil {
+		selectQuery = "TOP 1 1"
+		scope.Search.limit = nil
+	} else {
+		scope.Search.Limit(1)
+	}
+
+	if len(scope.Search.setOperations) > 0 {
+		scope.InstanceSet("gorm:set_operation_select", selectQuery)
+	} else {
+		scope.Search.Select(selectQuery)
+	}
+
+	rows, err := scope.rows()
+	if err = scope.Err(err); err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	found := rows.Next()
+	if err := rows.Err(); err != nil {
+		return false, scope.Err(err)
+	}
+	return found, nil
+}
+
+// existsCondition build the `EXISTS` condition of the sub query, query could be a `Repository` or `*Expression`
+func existsCondition(query interface{}, not bool) (string, *Expression, error) {
+	var expr *Expression
+	switch value := query.(type) {
+	case *Expression:
+		expr = value
+		if !strings.HasPrefix(strings.TrimSpace(expr.expr), "(") {
+			expr = Expr("("+expr.expr+")", expr.args...)
+		}
+	case Repository:
+		expr = value.SubQuery()
+	default:
+		return "", nil, fmt.Errorf("unsupported exists sub query %T, should be a Repository or *Expression", query)
+	}
+
+	if not {
+		return "NOT EXISTS ?", expr, nil
+	}
+	return "EXISTS ?", expr, nil
+}
+
+// whereExists add the `EXISTS` or `NOT EXISTS` condition to a clone of db
+func whereExists(db Repository, query interface{}, not bool) Repository {
+	clone := db.Clone()
+	condition, expr, err := existsCondition(query, not)
+	if clone.AddError(err) != nil {
+		return clone
+	}
+	return clone.Search().Where(condition, expr).db
+}
@@ -0,0 +1,48 @@
+package gorm_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zhinanxing/gorm/v3"
+)
+
+func TestExists(t *testing.T) {
+	user := User{Name: "ExistsUser", Emails: []Email{{Email: "exists@example.org"}}}
+	DB.Save(&user).Save(&User{Name: "ExistsUserWithoutEmail"})
+
+	recorder := &sqlRecorder{}
+	db := DB.New()
+	db.SetLogger(recorder)
+
+	found, err := db.LogMode(true).Model(&User{}).Where("name = ?", "ExistsUser").Exists()
+	if err != nil || !found {
+		t.Errorf("Should find existing record, but got %v, %v", found, err)
+	}
+
+	if sql := recorder.last(); !strings.Contains(sql, "SELECT 1 FROM") && !strings.Contains(sql, "SELECT TOP 1 1 FROM") {
+		t.Errorf("Should only select 1 when check exists, but got %v", sql)
+	}
+
+	if found, err := DB.Model(&User{}).Where("name = ?", "ExistsUserNotFound").Exists(); err != nil || found {
+		t.Errorf("Should not find record, but got %v, %v", found, err)
+	}
+
+	emails := DB.Table("emails").Select("1").Where("emails.user_id = users.id")
+
+	var names []string
+	DB.Model(&User{}).Where("name LIKE ?", "ExistsUser%").WhereExists(emails).Pluck("name", &names)
+	if len(names) != 1 || names[0] != "ExistsUser" {
+		t.Errorf("Should find users with emails, but got %v", names)
+	}
+
+	names = nil
+	DB.Model(&User{}).Where("name LIKE ?", "ExistsUser%").WhereNotExists(gorm.Expr("SELECT 1 FROM emails WHERE emails.user_id = users.id AND email = ?", "exists@example.org")).Pluck("name", &names)
+	if len(names) != 1 || names[0] != "ExistsUserWithoutEmail" {
+		t.Errorf("Should find users without emails, but got %v", names)
+	}
+
+	if err := DB.Model(&User{}).WhereExists("emails").Find(&[]User{}).Error(); err == nil {
+		t.Errorf("Should return error for unsupported sub query")
+	}
+}
@@ -0,0 +1,243 @@
+package gorm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrExplainNotSupported returned when the current dialect doesn't have a parsable EXPLAIN output
+var ErrExplainNotSupported = errors.New("explain is not supported by current dialect")
+
+// Plan contains the query plan returned by the database for a SELECT statement
+type Plan struct {
+	// SQL the explained statement
+	SQL string
+	// Raw the plan as returned by the database, JSON for mysql and postgres, one detail per line for sqlite3,
+	// tab separated rows with a header line for MySQL before 5.6.5
+	Raw string
+	// Nodes every table access found in the plan
+	Nodes []PlanNode
+	// FullScan true if any table is read by a full table (or full index) scan
+	FullScan bool
+	// UsesIndex true if any table is read by an index
+	UsesIndex bool
+}
+
+// PlanNode describe how a table is accessed in a query plan
+type PlanNode struct {
+	Table    string
+	Index    string
+	FullScan bool
+	Detail   string
+}
+
+// Indexes return names of all indexes used by the plan
+func (plan *Plan) Indexes() (indexes []string) {
+	for _, node := range plan.Nodes {
+		if node.Index != "" && !strInSlice(node.Index, indexes) {
+			indexes = append(indexes, node.Index)
+		}
+	}
+	return
+}
+
+func (plan *Plan) addNode(node PlanNode) {
+	plan.Nodes = append(plan.Nodes, node)
+	if node.FullScan {
+		plan.FullScan = true
+	}
+	if node.Index != "" {
+		plan.UsesIndex = true
+	}
+}
+
+// explain run current query under EXPLAIN and parse its result
+func (scope *Scope) explain() (*Plan, error) {
+	var (
+		prefix      string
+		tabular     bool
+		dialectName = scope.Dialect().GetName()
+	)
+
+	switch dialectName {
+	case "mysql":
+		// MySQL before 5.6.5 only has the tabular output
+		if prefix = "EXPLAIN FORMAT=JSON "; !scope.dialectSupports("explain_json", nil) {
+			prefix, tabular = "EXPLAIN ", true
+		}
+	case "postgres":
+		prefix = "EXPLAIN (FORMAT JSON) "
+	case "sqlite3":
+		prefix = "EXPLAIN QUERY PLAN "
+	default:
+		return nil, scope.Err(ErrExplainNotSupported)
+	}
+
+	scope.prepareQuerySQL()
+	if scope.HasError() {
+		return nil, scope.db.Error()
+	}
+
+	plan := &Plan{SQL: scope.SQL}
+	scope.InstanceSet("gorm:operation", "query")
+	scope.SQL = prefix + scope.SQL
+	scope.addComment()
+	defer scope.trace(NowFunc())
+
+	rows, err := scope.SQLDB().Query(scope.SQL, scope.SQLVars...)
+	if err = scope.Err(err); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err = scope.Err(err); err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	if tabular {
+		lines = append(lines, strings.Join(columns, "\t"))
+	}
+
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		for idx := range values {
+			values[idx] = new(interface{})
+		}
+		if err := scope.Err(rows.Scan(values...)); err != nil {
+			return nil, err
+		}
+
+		if tabular {
+			row := map[string]string{}
+			fields := make([]string, len(columns))
+			for idx, column := range columns {
+				fields[idx] = toString(*(values[idx].(*interface{})))
+				row[strings.ToLower(column)] = fields[idx]
+			}
+			lines = append(lines, strings.Join(fields, "\t"))
+			plan.addNode(PlanNode{Table: row["table"], Index: row["key"], Detail: row["type"], FullScan: row["type"] == "ALL" || row["type"] == "index"})
+			continue
+		}
+
+		// the plan detail is always the last column, `EXPLAIN QUERY PLAN` returns `id, parent, notused, detail`
+		lines = append(lines, toString(*(values[len(values)-1].(*interface{}))))
+	}
+
+	if err := scope.Err(rows.Err()); err != nil {
+		return nil, err
+	}
+
+	plan.Raw = strings.Join(lines, "\n")
+
+	switch {
+	case tabular:
+		// nodes are added while reading rows
+	case dialectName == "sqlite3":
+		for _, line := range lines {
+			plan.addNode(parseSqlite3PlanDetail(line))
+		}
+	default:
+		var result interface{}
+		if err := json.Unmarshal([]byte(plan.Raw), &result); err != nil {
+			return nil, scope.Err(fmt.Errorf("failed to parse query plan: %v", err))
+		}
+		if dialectName == "mysql" {
+			walkMysqlPlan(plan, result)
+		} else {
+			walkPostgresPlan(plan, result)
+		}
+	}
+
+	return plan, nil
+}
+
+// parseSqlite3PlanDetail parse sqlite3's plan detail, e.g:
+//     SCAN TABLE users
+//     SEARCH TABLE users USING INDEX idx_users_name (name=?)
+//     SEARCH users USING INTEGER PRIMARY KEY (rowid=?)
+func parseSqlite3PlanDetail(detail string) PlanNode {
+	node := PlanNode{Detail: detail}
+	fields := strings.Fields(detail)
+	if len(fields) < 2 || (fields[0] != "SCAN" && fields[0] != "SEARCH") {
+		return node
+	}
+
+	node.FullScan = fields[0] == "SCAN"
+	fields = fields[1:]
+	if fields[0] == "TABLE" && len(fields) > 1 {
+		fields = fields[1:]
+	}
+	node.Table = fields[0]
+
+	for idx, field := range fields {
+		if field != "USING" {
+			continue
+		}
+		rest := fields[idx+1:]
+		if len(rest) >= 3 && rest[0] == "INTEGER" && rest[1] == "PRIMARY" && rest[2] == "KEY" {
+			node.Index = "PRIMARY"
+		} else {
+			for i, word := range rest {
+				if word == "INDEX" && i+1 < len(rest) {
+					node.Index = rest[i+1]
+					break
+				}
+			}
+		}
+		break
+	}
+	return node
+}
+
+// walkMysqlPlan find all `table` objects in mysql's JSON plan
+func walkMysqlPlan(plan *Plan, value interface{}) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if accessType, ok := value["access_type"].(string); ok {
+			node := PlanNode{Detail: accessType, FullScan: accessType == "ALL" || accessType == "index"}
+			node.Table, _ = value["table_name"].(string)
+			node.Index, _ = value["key"].(string)
+			plan.addNode(node)
+		}
+		for _, key := range sortedKeys(value) {
+			walkMysqlPlan(plan, value[key])
+		}
+	case []interface{}:
+		for _, v := range value {
+			walkMysqlPlan(plan, v)
+		}
+	}
+}
+
+// walkPostgresPlan find all scan nodes in postgres's JSON plan
+func walkPostgresPlan(plan *Plan, value interface{}) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if nodeType, ok := value["Node Type"].(string); ok && strings.HasSuffix(nodeType, "Scan") {
+			node := PlanNode{Detail: nodeType, FullScan: nodeType == "Seq Scan"}
+			node.Table, _ = value["Relation Name"].(string)
+			node.Index, _ = value["Index Name"].(string)
+			plan.addNode(node)
+		}
+		for _, key := range sortedKeys(value) {
+			walkPostgresPlan(plan, value[key])
+		}
+	case []interface{}:
+		for _, v := range value {
+			walkPostgresPlan(plan, v)
+		}
+	}
+}
+
+func sortedKeys(value map[string]interface{}) (keys []string) {
+	for key := range value {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return
+}
@@ -0,0 +1,50 @@
+package gorm_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zhinanxing/gorm/v3"
+)
+
+func TestExplain(t *testing.T) {
+	DB.Save(&User{Name: "ExplainUser", Age: 10})
+
+	var users []User
+	plan, err := DB.Where("name = ?", "ExplainUser").Explain(&users)
+	if dialect := DB.Dialect().GetName(); dialect != "mysql" && dialect != "postgres" && dialect != "sqlite3" {
+		if err != gorm.ErrExplainNotSupported {
+			t.Errorf("Should return ErrExplainNotSupported for %v, but got %v", dialect, err)
+		}
+		return
+	}
+
+	if err != nil {
+		t.Fatalf("No error should happen when explain query, but got %v", err)
+	}
+
+	if len(plan.Nodes) == 0 || plan.Raw == "" {
+		t.Errorf("Should parse query plan, but got %#v", plan)
+	}
+
+	if tabular := DB.Dialect().GetName() == "mysql" && !DialectSupports("explain_json"); tabular != strings.HasPrefix(plan.Raw, "id\t") {
+		t.Errorf("Should use tabular EXPLAIN only if JSON format isn't supported, but got %v", plan.Raw)
+	}
+
+	if !plan.FullScan {
+		t.Errorf("Should be a full scan when querying with non-indexed column, but got %v", plan.Raw)
+	}
+
+	if len(users) != 0 {
+		t.Errorf("Explain should not load any records")
+	}
+
+	// postgres prefers seq scan for small tables even if index is available
+	if DB.Dialect().GetName() != "postgres" {
+		var user User
+		plan, err = DB.Where("id = ?", 1).Explain(&user)
+		if err != nil || !plan.UsesIndex || plan.FullScan {
+			t.Errorf("Should use primary key index when querying with id, but got %v, %v", plan.Raw, err)
+		}
+	}
+}
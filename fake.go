@@ -15,6 +15,7 @@
 package gorm
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"github.com/jinzhu/copier"
@@ -154,6 +155,23 @@ func (r *FakeRepository) Where(query interface{}, args ...interface{}) Repositor
 	return r
 }
 
+// WhereExists filter records with `EXISTS` condition of the sub query
+func (r *FakeRepository) WhereExists(query interface{}) Repository {
+	return r
+}
+
+// WhereNotExists filter records with `NOT EXISTS` condition of the sub query
+func (r *FakeRepository) WhereNotExists(query interface{}) Repository {
+	return r
+}
+
+// Exists check if there are any records match current conditions
+func (r *FakeRepository) Exists() (bool, error) {
+	var found bool
+	r.copyData("Exists", &found)
+	return found, r.Error()
+}
+
 // Or filter records that match before conditions or this one, similar to `Where`
 func (r *FakeRepository) Or(query interface{}, args ...interface{}) Repository {
 	return r
@@ -252,12 +270,67 @@ func (r *FakeRepository) Find(out interface{}, where ...interface{}) Repository
 	return r
 }
 
+// FindInBatches find records in batches ordered by primary key, fc is called with every batch loaded into dest
+func (r *FakeRepository) FindInBatches(dest interface{}, batchSize int, fc func(tx Repository, batch int) error) Repository {
+	r.copyData("FindInBatches", dest)
+	if err := fc(r, 1); err != nil {
+		r.AddError(err)
+	}
+	return r
+}
+
+// Iterate query records with given conditions, and return an iterator that decodes one record at a time into dest
+func (r *FakeRepository) Iterate(dest interface{}) (*RowIterator, error) {
+	return &RowIterator{}, r.Error()
+}
+
+// Descendants find descendants of root with the self-referencing has_many association
+func (r *FakeRepository) Descendants(root interface{}, dest interface{}, maxDepth int) Repository {
+	r.copyData("Descendants", dest)
+	return r
+}
+
+// Ancestors find ancestors of node with the self-referencing has_many association
+func (r *FakeRepository) Ancestors(node interface{}, dest interface{}) Repository {
+	r.copyData("Ancestors", dest)
+	return r
+}
+
 // Scan scan value to a struct
 func (r *FakeRepository) Scan(dest interface{}) Repository {
 	r.copyData("Scan", dest)
 	return r
 }
 
+// ExportSchema export schema of models as JSON
+func (r *FakeRepository) ExportSchema(models ...interface{}) ([]byte, error) {
+	var data []byte
+	r.copyData("ExportSchema", &data)
+	return data, r.Error()
+}
+
+// Explain run the query that `Find` would run under `EXPLAIN`, and return the parsed plan
+func (r *FakeRepository) Explain(out interface{}) (*Plan, error) {
+	plan := &Plan{}
+	r.copyData("Explain", plan)
+	return plan, r.Error()
+}
+
+// Page find records of given page with `Limit`/`Offset`, and count total records with the same conditions
+func (r *FakeRepository) Page(dest interface{}, page, size int) (PageInfo, error) {
+	info := PageInfo{Page: page, Size: size}
+	r.copyData("Page", dest)
+	r.copyData("PageInfo", &info)
+	return info, r.Error()
+}
+
+// Paginate find a page of records with keyset pagination
+func (r *FakeRepository) Paginate(out interface{}, cursor string, pageSize int, orderFields ...string) (*KeysetPage, error) {
+	page := &KeysetPage{}
+	r.copyData("Paginate", out)
+	return page, r.Error()
+}
+
 // Row return `*sql.Row` with given conditions
 func (r *FakeRepository) Row() *sql.Row {
 	return nil
@@ -280,6 +353,31 @@ func (r *FakeRepository) Pluck(column string, value interface{}) Repository {
 	return r
 }
 
+// Sum get sum of the column
+func (r *FakeRepository) Sum(column string, dest interface{}) Repository {
+	return r
+}
+
+// Avg get average of the column
+func (r *FakeRepository) Avg(column string, dest interface{}) Repository {
+	return r
+}
+
+// Min get minimum of the column
+func (r *FakeRepository) Min(column string, dest interface{}) Repository {
+	return r
+}
+
+// Max get maximum of the column
+func (r *FakeRepository) Max(column string, dest interface{}) Repository {
+	return r
+}
+
+// PluckColumns query multiple columns into a slice of structs or a `[][]interface{}`
+func (r *FakeRepository) PluckColumns(value interface{}, columns ...string) Repository {
+	return r
+}
+
 // Count get how many records for a model
 func (r *FakeRepository) Count(value interface{}) Repository {
 	return r
@@ -314,6 +412,11 @@ func (r *FakeRepository) Updates(values interface{}, ignoreProtectedAttrs ...boo
 	return r
 }
 
+// UpdatesFields update attributes with callbacks, fields named of the struct are updated even if they are blank
+func (r *FakeRepository) UpdatesFields(values interface{}, fields ...string) Repository {
+	return r
+}
+
 // UpdateColumn update attributes without callbacks, refer: https://jinzhu.github.io/gorm/crud.html#update
 func (r *FakeRepository) UpdateColumn(attrs ...interface{}) Repository {
 	return r
@@ -364,6 +467,46 @@ func (r *FakeRepository) Table(name string) Repository {
 	return r
 }
 
+// From specify a derived table as the source of the query
+func (r *FakeRepository) From(query interface{}, alias string) Repository {
+	return r
+}
+
+// Union combine current query with queries using UNION
+func (r *FakeRepository) Union(queries ...Repository) Repository {
+	return r
+}
+
+// UnionAll combine current query with queries using UNION ALL
+func (r *FakeRepository) UnionAll(queries ...Repository) Repository {
+	return r
+}
+
+// Intersect combine current query with queries using INTERSECT
+func (r *FakeRepository) Intersect(queries ...Repository) Repository {
+	return r
+}
+
+// Except combine current query with queries using EXCEPT
+func (r *FakeRepository) Except(queries ...Repository) Repository {
+	return r
+}
+
+// With add a common table expression to the query
+func (r *FakeRepository) With(name string, query interface{}) Repository {
+	return r
+}
+
+// WithRecursive add a recursive common table expression to the query
+func (r *FakeRepository) WithRecursive(name string, query interface{}) Repository {
+	return r
+}
+
+// WithContext set the context for following operations
+func (r *FakeRepository) WithContext(ctx context.Context) Repository {
+	return r
+}
+
 // Debug start debug mode
 func (r *FakeRepository) Debug() Repository {
 	return r
@@ -461,6 +604,16 @@ func (r *FakeRepository) Association(column string) *Association {
 	return nil
 }
 
+// PreloadCount count has_many or many_to_many associations with a GROUP BY query instead of loading them
+func (r *FakeRepository) PreloadCount(column string, conditions ...interface{}) Repository {
+	return r
+}
+
+// JoinsPreload preload belongs_to or has_one association with LEFT JOIN in the same query
+func (r *FakeRepository) JoinsPreload(column string) Repository {
+	return r
+}
+
 // Preload preload associations with given conditions
 //    db.Preload("Orders", "state NOT IN (?)", "cancelled").Find(&users)
 func (r *FakeRepository) Preload(column string, conditions ...interface{}) Repository {
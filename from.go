@@ -0,0 +1,30 @@
+package gorm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// fromSQL render the source of the query, it is the quoted table name, or the derived table specified with `From`
+func (scope *Scope) fromSQL() string {
+	if scope.Search.fromQuery == nil {
+		return scope.QuotedTableName()
+	}
+
+	var sql string
+	switch query := scope.Search.fromQuery.(type) {
+	case *Expression:
+		sql = scope.AddToVars(query)
+	case Repository:
+		sql = scope.AddToVars(query.QueryExpr())
+	default:
+		scope.Err(fmt.Errorf("unsupported derived table %v, should be a Repository or *Expression", scope.Search.tableName))
+		return scope.QuotedTableName()
+	}
+
+	// `SubQuery` is already wrapped with parentheses
+	if !strings.HasPrefix(strings.TrimSpace(sql), "(") {
+		sql = "(" + sql + ")"
+	}
+	return fmt.Sprintf("%v AS %v", sql, scope.QuotedTableName())
+}
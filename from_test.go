@@ -0,0 +1,41 @@
+package gorm_test
+
+import (
+	"testing"
+)
+
+func TestFromDerivedTable(t *testing.T) {
+	users := []User{
+		{Name: "FromUser1", Emails: []Email{{Email: "from1a@example.org"}, {Email: "from1b@example.org"}}},
+		{Name: "FromUser2", Emails: []Email{{Email: "from2a@example.org"}, {Email: "from2b@example.org"}, {Email: "from2c@example.org"}}},
+	}
+	for i := range users {
+		DB.Save(&users[i])
+	}
+
+	latest := DB.Table("emails").Select("user_id, max(id) AS id").Where("user_id IN (?)", []int64{users[0].Id, users[1].Id}).Group("user_id")
+
+	var emails []Email
+	if err := DB.Table("emails").Where("id IN ?", DB.From(latest, "latest_emails").Select("id").SubQuery()).Order("user_id").Find(&emails).Error(); err != nil {
+		t.Fatalf("No error should happen when query derived table, but got %v", err)
+	}
+
+	if len(emails) != 2 || emails[0].Email != "from1b@example.org" || emails[1].Email != "from2c@example.org" {
+		t.Errorf("Should find latest email per user, but got %#v", emails)
+	}
+
+	var results []struct {
+		UserId int
+		Id     int
+	}
+	DB.From(latest.SubQuery(), "latest_emails").Where("user_id = ?", users[1].Id).Find(&results)
+	if len(results) != 1 || results[0].Id != int(users[1].Emails[2].Id) {
+		t.Errorf("Conditions should apply to derived table, but got %#v", results)
+	}
+
+	var count int
+	DB.From(DB.Table("emails").Where("user_id IN (?)", []int64{users[0].Id, users[1].Id}), "user_emails").Group("user_id").Count(&count)
+	if count != 2 {
+		t.Errorf("Should count groups of derived table, but got %v", count)
+	}
+}
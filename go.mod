@@ -1,6 +1,6 @@
 module github.com/zhinanxing/gorm/v3
 
-go 1.14
+go 1.18
 
 require (
 	github.com/denisenkom/go-mssqldb v0.0.0-20200620013148-b91950f658ec
@@ -12,3 +12,8 @@ require (
 	github.com/lib/pq v1.7.0
 	github.com/mattn/go-sqlite3 v1.14.0
 )
+
+require (
+	github.com/golang-sql/civil v0.0.0-20190719163853-cb61b32ac6fe // indirect
+	golang.org/x/crypto v0.0.0-20190325154230-a5d413f7728c // indirect
+)
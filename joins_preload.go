@@ -0,0 +1,159 @@
+package gorm
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// joinsPreloadSeparator separates association name and column name in aliases of columns loaded with `JoinsPreload`, e.g: `Company__name`
+const joinsPreloadSeparator = "__"
+
+// joinsPreloadField an association loaded with LEFT JOIN
+type joinsPreloadField struct {
+	field *Field
+	scope *Scope
+}
+
+// joinsPreloadFields return associations loaded with `JoinsPreload`, only belongs_to and has_one associations are supported
+func (scope *Scope) joinsPreloadFields() (fields []joinsPreloadField) {
+	for _, name := range scope.Search.joinsPreload {
+		field, ok := scope.FieldByName(name)
+		if !ok || field.Relationship == nil {
+			scope.Err(fmt.Errorf("can't preload field %s for %s", name, scope.GetModelStruct().ModelType))
+			continue
+		}
+
+		if kind := field.Relationship.Kind; kind != "belongs_to" && kind != "has_one" {
+			scope.Err(fmt.Errorf("can't preload %v association %s with joins", kind, name))
+			continue
+		}
+
+		fieldType := field.Struct.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		fields = append(fields, joinsPreloadField{field: field, scope: scope.New(reflect.New(fieldType).Interface())})
+	}
+	return
+}
+
+// resolvedJoinsPreloadFields return associations resolved by the query callback, resolve them if not queried by it
+func (scope *Scope) resolvedJoinsPreloadFields() []joinsPreloadField {
+	if value, ok := scope.InstanceGet("gorm:joins_preload"); ok {
+		if fields, ok := value.([]joinsPreloadField); ok {
+			return fields
+		}
+	}
+	return scope.joinsPreloadFields()
+}
+
+// joinsPreloadSQL build LEFT JOIN clauses for associations loaded with `JoinsPreload`, joined tables are aliased with association's name
+func (scope *Scope) joinsPreloadSQL() string {
+	var (
+		joins           []string
+		quotedTableName = scope.QuotedTableName()
+	)
+
+	for _, preload := range scope.resolvedJoinsPreloadFields() {
+		var (
+			relation   = preload.field.Relationship
+			alias      = scope.Quote(preload.field.Name)
+			conditions []string
+		)
+
+		for idx, foreignDBName := range relation.ForeignDBNames {
+			if relation.Kind == "belongs_to" {
+				conditions = append(conditions, fmt.Sprintf("%v.%v = %v.%v", alias, scope.Quote(relation.AssociationForeignDBNames[idx]), quotedTableName, scope.Quote(foreignDBName)))
+			} else {
+				conditions = append(conditions, fmt.Sprintf("%v.%v = %v.%v", alias, scope.Quote(foreignDBName), quotedTableName, scope.Quote(relation.AssociationForeignDBNames[idx])))
+			}
+		}
+
+		if relation.PolymorphicType != "" {
+			conditions = append(conditions, fmt.Sprintf("%v.%v = %v", alias, scope.Quote(relation.PolymorphicDBName), scope.AddToVars(relation.PolymorphicValue)))
+		}
+
+		if deletedAtField, ok := preload.scope.FieldByName("DeletedAt"); ok && !scope.Search.Unscoped {
+			conditions = append(conditions, fmt.Sprintf("%v.%v IS NULL", alias, scope.Quote(deletedAtField.DBName)))
+		}
+
+		joins = append(joins, fmt.Sprintf("LEFT JOIN %v %v ON %v", preload.scope.QuotedTableName(), alias, strings.Join(conditions, " AND ")))
+	}
+
+	return strings.Join(joins, " ")
+}
+
+// joinsPreloadSelectSQL select columns of associations loaded with `JoinsPreload`, aliased like `Company__name`,
+// it is prefixed with a comma so it could be appended to selected columns
+func (scope *Scope) joinsPreloadSelectSQL(fields []joinsPreloadField) string {
+	var columns []string
+	for _, preload := range fields {
+		alias := preload.field.Name
+		for _, field := range preload.scope.GetModelStruct().StructFields {
+			if field.IsNormal && !field.IsIgnored {
+				columns = append(columns, fmt.Sprintf("%v.%v AS %v", scope.Quote(alias), scope.Quote(field.DBName), scope.Quote(alias+joinsPreloadSeparator+field.DBName)))
+			}
+		}
+	}
+
+	if len(columns) == 0 {
+		return ""
+	}
+	return ", " + strings.Join(columns, ", ")
+}
+
+// joinedValue holds values of an association loaded with `JoinsPreload` while scanning a row
+type joinedValue struct {
+	field  *Field
+	value  reflect.Value
+	fields []*Field
+	loaded bool
+}
+
+// joinedColumnValue return the scan destination for column like `Company__name` and the field it will be assigned to
+func (scope *Scope) joinedColumnValue(column string, fields []*Field, joinedValues map[string]*joinedValue) (interface{}, *Field, *joinedValue) {
+	idx := strings.Index(column, joinsPreloadSeparator)
+	if idx <= 0 {
+		return nil, nil, nil
+	}
+
+	name := column[:idx]
+	joined, ok := joinedValues[name]
+	if !ok {
+		for _, field := range fields {
+			if field.Name == name && field.Relationship != nil && field.Field.IsValid() {
+				fieldType := field.Struct.Type
+				if fieldType.Kind() == reflect.Ptr {
+					fieldType = fieldType.Elem()
+				}
+				value := reflect.New(fieldType)
+				joined = &joinedValue{field: field, value: value, fields: scope.New(value.Interface()).Fields()}
+				break
+			}
+		}
+		joinedValues[name] = joined
+	}
+
+	if joined != nil {
+		for _, field := range joined.fields {
+			if field.DBName == column[idx+len(joinsPreloadSeparator):] && field.IsNormal {
+				return reflect.New(reflect.PtrTo(field.Struct.Type)).Interface(), field, joined
+			}
+		}
+	}
+	return nil, nil, nil
+}
+
+// assign set scanned association to its field, associations without any non-NULL column are left blank
+func (joined *joinedValue) assign() {
+	if !joined.loaded {
+		return
+	}
+
+	if joined.field.Field.Kind() == reflect.Ptr {
+		joined.field.Field.Set(joined.value)
+	} else {
+		joined.field.Field.Set(joined.value.Elem())
+	}
+}
@@ -0,0 +1,71 @@
+package gorm_test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJoinsPreload(t *testing.T) {
+	company := Company{Name: "JoinsPreloadCompany"}
+	DB.Save(&company)
+
+	companyID := int(company.Id)
+	user1 := User{Name: "JoinsPreloadUser1", CompanyID: &companyID, CreditCard: CreditCard{Number: "411111111112"}}
+	user2 := User{Name: "JoinsPreloadUser2"}
+	DB.Save(&user1).Save(&user2)
+
+	recorder := &sqlRecorder{}
+	db := DB.New()
+	db.SetLogger(recorder)
+
+	var users []User
+	if err := db.LogMode(true).JoinsPreload("Company").JoinsPreload("CreditCard").
+		Where("users.name IN (?)", []string{user1.Name, user2.Name}).Order("users.id").Find(&users).Error(); err != nil {
+		t.Fatalf("No error should happen when preload with joins, but got %v", err)
+	}
+
+	if len(recorder.sqls) != 1 || !strings.Contains(recorder.last(), "LEFT JOIN") {
+		t.Errorf("Should load associations in the same query, but got %v", recorder.sqls)
+	}
+
+	if len(users) != 2 {
+		t.Fatalf("Should find 2 users, but got %v", len(users))
+	}
+
+	if users[0].Company.Id != company.Id || users[0].Company.Name != company.Name {
+		t.Errorf("Should load belongs_to association, but got %#v", users[0].Company)
+	}
+
+	if users[0].CreditCard.Number != "411111111112" || users[0].CreditCard.UserId.Int64 != user1.Id {
+		t.Errorf("Should load has_one association, but got %#v", users[0].CreditCard)
+	}
+
+	if users[1].Company.Id != 0 || users[1].CreditCard.ID != 0 {
+		t.Errorf("Associations should be blank if not found, but got %#v, %#v", users[1].Company, users[1].CreditCard)
+	}
+
+	var user User
+	DB.JoinsPreload("Company").Where(DB.Dialect().Quote("Company")+".name = ?", company.Name).First(&user)
+	if user.Id != user1.Id || user.Company.Name != company.Name {
+		t.Errorf("Should query with conditions on joined table, but got %#v", user)
+	}
+
+	var count int
+	DB.Model(&User{}).JoinsPreload("Company").Where(DB.Dialect().Quote("Company")+".name = ?", company.Name).Count(&count)
+	if count != 1 {
+		t.Errorf("Should count with joined table, but got %v", count)
+	}
+
+	if errors := DB.JoinsPreload("Emails").Find(&users).GetErrors(); len(errors) != 1 {
+		t.Errorf("Should return one error when preload has_many association with joins, but got %v", errors)
+	}
+
+	base := DB.JoinsPreload("Company").JoinsPreload("CreditCard").JoinsPreload("BillingAddress")
+	invalid, valid := base.JoinsPreload("Emails"), base.JoinsPreload("ShippingAddress")
+	if err := invalid.Find(&users).Error(); err == nil {
+		t.Errorf("Branched repositories shouldn't overwrite preloads of each other")
+	}
+	if err := valid.Find(&users).Error(); err != nil {
+		t.Errorf("No error should happen when preload with joins, but got %v", err)
+	}
+}
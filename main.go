@@ -1,7 +1,9 @@
 package gorm
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
@@ -14,10 +16,12 @@ type Repository interface {
 	AddForeignKey(field string, dest string, onDelete string, onUpdate string) Repository
 	AddIndex(indexName string, columns ...string) Repository
 	AddUniqueIndex(indexName string, columns ...string) Repository
+	Ancestors(node interface{}, dest interface{}) Repository
 	Assign(attrs ...interface{}) Repository
 	Association(column string) *Association
 	Attrs(attrs ...interface{}) Repository
 	AutoMigrate(values ...interface{}) Repository
+	Avg(column string, dest interface{}) Repository
 	Begin() Repository
 	BlockGlobalUpdate(enable bool) Repository
 	Callback() *Callback
@@ -30,15 +34,22 @@ type Repository interface {
 	SqlDB() *sql.DB
 	Debug() Repository
 	Delete(value interface{}, where ...interface{}) Repository
+	Descendants(root interface{}, dest interface{}, maxDepth int) Repository
 	Dialect() Dialect
 	DropColumn(column string) Repository
 	DropTable(values ...interface{}) Repository
 	DropTableIfExists(values ...interface{}) Repository
+	Except(queries ...Repository) Repository
 	Exec(sql string, values ...interface{}) Repository
+	Exists() (bool, error)
+	Explain(out interface{}) (*Plan, error)
+	ExportSchema(models ...interface{}) ([]byte, error)
 	Find(out interface{}, where ...interface{}) Repository
+	FindInBatches(dest interface{}, batchSize int, fc func(tx Repository, batch int) error) Repository
 	First(out interface{}, where ...interface{}) Repository
 	FirstOrCreate(out interface{}, where ...interface{}) Repository
 	FirstOrInit(out interface{}, where ...interface{}) Repository
+	From(query interface{}, alias string) Repository
 	Get(name string) (value interface{}, ok bool)
 	GetErrors() []error
 	Group(query string) Repository
@@ -46,10 +57,15 @@ type Repository interface {
 	HasTable(value interface{}) bool
 	Having(query interface{}, values ...interface{}) Repository
 	InstantSet(name string, value interface{}) Repository
+	Intersect(queries ...Repository) Repository
+	Iterate(dest interface{}) (*RowIterator, error)
 	Joins(query string, args ...interface{}) Repository
+	JoinsPreload(column string) Repository
 	Last(out interface{}, where ...interface{}) Repository
 	Limit(limit interface{}) Repository
 	LogMode(enable bool) Repository
+	Max(column string, dest interface{}) Repository
+	Min(column string, dest interface{}) Repository
 	Model(value interface{}) Repository
 	ModifyColumn(column string, typ string) Repository
 	New() Repository
@@ -60,8 +76,12 @@ type Repository interface {
 	Omit(columns ...string) Repository
 	Or(query interface{}, args ...interface{}) Repository
 	Order(value interface{}, reorder ...bool) Repository
+	Page(dest interface{}, page, size int) (PageInfo, error)
+	Paginate(out interface{}, cursor string, pageSize int, orderFields ...string) (*KeysetPage, error)
 	Pluck(column string, value interface{}) Repository
+	PluckColumns(value interface{}, columns ...string) Repository
 	Preload(column string, conditions ...interface{}) Repository
+	PreloadCount(column string, conditions ...interface{}) Repository
 	QueryExpr() *Expression
 	Raw(sql string, values ...interface{}) Repository
 	RecordNotFound() bool
@@ -81,14 +101,23 @@ type Repository interface {
 	SetLogger(log Logger) Repository
 	SingularTable(enable bool)
 	SubQuery() *Expression
+	Sum(column string, dest interface{}) Repository
 	Table(name string) Repository
 	Take(out interface{}, where ...interface{}) Repository
+	Union(queries ...Repository) Repository
+	UnionAll(queries ...Repository) Repository
 	Unscoped() Repository
 	Update(attrs ...interface{}) Repository
 	UpdateColumn(attrs ...interface{}) Repository
 	UpdateColumns(values interface{}) Repository
 	Updates(values interface{}, ignoreProtectedAttrs ...bool) Repository
+	UpdatesFields(values interface{}, fields ...string) Repository
 	Where(query interface{}, args ...interface{}) Repository
+	WhereExists(query interface{}) Repository
+	WhereNotExists(query interface{}) Repository
+	With(name string, query interface{}) Repository
+	WithContext(ctx context.Context) Repository
+	WithRecursive(name string, query interface{}) Repository
 	Value() interface{}
 	SetValue(v interface{}) Repository
 	Error() error
@@ -301,10 +330,25 @@ func (r *repository) SubQuery() *Expression {
 }
 
 // Where return a new relation, filter records with given conditions, accepts `map`, `struct` or `string` as conditions, refer http://jinzhu.github.io/gorm/crud.html#query
+// blank fields of struct conditions are skipped, unless they are named in args
+//     db.Where(&User{Name: "jinzhu", Active: false}, "Active").Find(&users)
+//     // SELECT * FROM users WHERE name = 'jinzhu' AND active = false;
 func (r *repository) Where(query interface{}, args ...interface{}) Repository {
 	return r.Clone().Search().Where(query, args...).db
 }
 
+// WhereExists filter records with `EXISTS` condition of the sub query, query could be a `Repository` or `*Expression`
+//     db.WhereExists(db.Table("orders").Select("1").Where("orders.user_id = users.id")).Find(&users)
+//     // SELECT * FROM "users" WHERE (EXISTS (SELECT 1 FROM "orders" WHERE (orders.user_id = users.id)))
+func (r *repository) WhereExists(query interface{}) Repository {
+	return whereExists(r, query, false)
+}
+
+// WhereNotExists filter records with `NOT EXISTS` condition of the sub query, refer `WhereExists`
+func (r *repository) WhereNotExists(query interface{}) Repository {
+	return whereExists(r, query, true)
+}
+
 // Or filter records that match before conditions or this one, similar to `Where`
 func (r *repository) Or(query interface{}, args ...interface{}) Repository {
 	return r.Clone().Search().Or(query, args...).db
@@ -425,11 +469,83 @@ func (r *repository) Find(out interface{}, where ...interface{}) Repository {
 	return r.NewScope(out).inlineCondition(where...).callCallbacks(r.parent.Callbacks().queries).db
 }
 
+// FindInBatches find records in batches ordered by primary key, fc is called with every batch loaded into dest
+//     db.Where("active = ?", true).FindInBatches(&users, 1000, func(tx gorm.Repository, batch int) error {
+//       // users contains current batch
+//       return nil
+//     })
+func (r *repository) FindInBatches(dest interface{}, batchSize int, fc func(tx Repository, batch int) error) Repository {
+	return r.NewScope(dest).findInBatches(batchSize, fc).db
+}
+
+// Iterate query records with given conditions, and return an iterator that decodes one record at a time into dest,
+// preloading isn't supported as records are not loaded into memory
+func (r *repository) Iterate(dest interface{}) (*RowIterator, error) {
+	return r.NewScope(dest).iterate()
+}
+
+// Descendants find descendants of root with the self-referencing has_many association, e.g: `Children []Category` with `ParentID`,
+// up to maxDepth levels, all levels if maxDepth <= 0. Records are ordered by depth if no order specified, the depth could be
+// loaded into a field like `Depth int `gorm:"-"``. Each node is returned once even if the tree has cycles
+//     db.Descendants(&category, &categories, 3)
+func (r *repository) Descendants(root interface{}, dest interface{}, maxDepth int) Repository {
+	return r.NewScope(dest).tree(root, maxDepth, false).db
+}
+
+// Ancestors find ancestors of node with the self-referencing has_many association, from its parent to the root
+//     db.Ancestors(&category, &categories)
+func (r *repository) Ancestors(node interface{}, dest interface{}) Repository {
+	return r.NewScope(dest).tree(node, 0, true).db
+}
+
 // Scan scan value to a struct
 func (r *repository) Scan(dest interface{}) Repository {
 	return r.NewScope(r.value).Set("gorm:query_destination", dest).callCallbacks(r.parent.Callbacks().queries).db
 }
 
+// Explain run the query that `Find` would run under `EXPLAIN`, and return the parsed plan
+//     plan, err := db.Where("email = ?", "jinzhu@example.org").Explain(&users)
+//     if plan.FullScan {
+//       // missing index
+//     }
+func (r *repository) Explain(out interface{}) (*Plan, error) {
+	return r.NewScope(out).explain()
+}
+
+// ExportSchema export tables, columns, indexes, foreign keys and relationships of models as indented JSON,
+// set `gorm:export_json_schema` to include JSON Schema of the Go structs
+//     data, err := db.Set("gorm:export_json_schema", true).ExportSchema(&User{}, &Email{})
+func (r *repository) ExportSchema(models ...interface{}) ([]byte, error) {
+	document, err := r.NewScope(nil).exportSchema(models...)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(document, "", "  ")
+}
+
+// Exists check if there are any records match current conditions, without loading them
+//     found, err := db.Model(&User{}).Where("email = ?", "jinzhu@example.org").Exists()
+//     // SELECT 1 FROM "users" WHERE (email = 'jinzhu@example.org') LIMIT 1
+func (r *repository) Exists() (bool, error) {
+	return r.NewScope(r.value).exists()
+}
+
+// Page find records of given page with `Limit`/`Offset`, and count total records with the same conditions
+//     info, err := db.Where("active = ?", true).Order("id").Page(&users, 2, 20)
+//     // info.Total, info.Pages, info.HasNext
+func (r *repository) Page(dest interface{}, page, size int) (PageInfo, error) {
+	return r.NewScope(dest).page(page, size)
+}
+
+// Paginate find a page of records with keyset pagination, ordered by orderFields, the primary key is appended if not included.
+// Pass blank cursor to get the first page, then cursors of the returned KeysetPage to get next/prev pages.
+// Order fields must be NOT NULL, nullable ones (pointers, scanners) are rejected unless tagged with `not null`
+//     page, err := db.Where("active = ?", true).Paginate(&users, "", 20, "created_at DESC")
+//     page, err = db.Where("active = ?", true).Paginate(&users, page.NextCursor, 20, "created_at DESC")
+func (r *repository) Paginate(out interface{}, cursor string, pageSize int, orderFields ...string) (*KeysetPage, error) {
+	return r.NewScope(out).paginate(cursor, pageSize, orderFields)
+}
+
 // Row return `*sql.Row` with given conditions
 func (r *repository) Row() *sql.Row {
 	return r.NewScope(r.value).row()
@@ -462,6 +578,47 @@ func (r *repository) Pluck(column string, value interface{}) Repository {
 	return r.NewScope(r.value).pluck(column, value).db
 }
 
+// Sum get sum of the column, dest is set to zero value when no rows matched, when the query is grouped, dest could be a slice to receive sum of each group
+//     var total int64
+//     db.Model(&Order{}).Where("state = ?", "paid").Sum("amount", &total)
+//     var totals []float64
+//     db.Model(&Order{}).Group("user_id").Order("user_id").Sum("amount", &totals)
+func (r *repository) Sum(column string, dest interface{}) Repository {
+	return r.NewScope(r.value).aggregate("SUM", column, dest).db
+}
+
+// Avg get average of the column, refer `Sum`
+//     var average float64
+//     db.Model(&User{}).Avg("age", &average)
+func (r *repository) Avg(column string, dest interface{}) Repository {
+	return r.NewScope(r.value).aggregate("AVG", column, dest).db
+}
+
+// Min get minimum of the column, refer `Sum`, time columns returned as text by sqlite are parsed for time.Time or
+// *time.Time destination, which is nil if no rows
+//     var first time.Time
+//     db.Model(&Order{}).Min("created_at", &first)
+func (r *repository) Min(column string, dest interface{}) Repository {
+	return r.NewScope(r.value).aggregate("MIN", column, dest).db
+}
+
+// Max get maximum of the column, refer `Sum`
+//     var oldest int
+//     db.Model(&User{}).Max("age", &oldest)
+func (r *repository) Max(column string, dest interface{}) Repository {
+	return r.NewScope(r.value).aggregate("MAX", column, dest).db
+}
+
+// PluckColumns query multiple columns into a slice of structs, columns are matched with fields by name or alias,
+// or into a `[][]interface{}`, if no columns given, the selected columns are used
+//     var options []struct{ Value int64; Label string }
+//     db.Model(&User{}).PluckColumns(&options, "id AS value", "name AS label")
+//     var rows [][]interface{}
+//     db.Model(&User{}).PluckColumns(&rows, "id", "name")
+func (r *repository) PluckColumns(value interface{}, columns ...string) Repository {
+	return r.NewScope(r.value).pluckColumns(value, columns...).db
+}
+
 // Count get how many records for a model
 func (r *repository) Count(value interface{}) Repository {
 	return r.NewScope(r.value).count(value).db
@@ -515,6 +672,22 @@ func (r *repository) Updates(values interface{}, ignoreProtectedAttrs ...bool) R
 		callCallbacks(r.parent.Callbacks().updates).db
 }
 
+// UpdatesFields update attributes with callbacks like `Updates`, fields named of the struct are updated even if they are blank
+//     db.Model(&user).UpdatesFields(User{Name: "hello", Active: false}, "Active")
+//     // UPDATE users SET name='hello', active=false, updated_at='2013-11-17 21:34:10' WHERE id=111;
+func (r *repository) UpdatesFields(values interface{}, fields ...string) Repository {
+	names := make([]interface{}, len(fields))
+	for idx, field := range fields {
+		names[idx] = field
+	}
+
+	return r.NewScope(r.value).
+		Set("gorm:ignore_protected_attrs", false).
+		InstanceSet("gorm:update_interface", values).
+		InstanceSet("gorm:update_fields", names).
+		callCallbacks(r.parent.Callbacks().updates).db
+}
+
 // UpdateColumn update attributes without callbacks, refer: https://jinzhu.github.io/gorm/crud.html#update
 func (r *repository) UpdateColumn(attrs ...interface{}) Repository {
 	return r.UpdateColumns(toSearchableMap(attrs...))
@@ -587,6 +760,60 @@ func (r *repository) Table(name string) Repository {
 	return clone
 }
 
+// From specify a derived table as the source of the query, query could be a `Repository` or `*Expression`,
+// the alias is used as table name, so conditions, groups and orders in the chain apply to the derived table
+//     latest := db.Table("orders").Select("user_id, max(id) AS id").Group("user_id")
+//     db.From(latest, "latest_orders").Where("id > ?", 100).Find(&results)
+//     // SELECT * FROM (SELECT user_id, max(id) AS id FROM "orders" GROUP BY user_id) AS "latest_orders" WHERE (id > 100)
+func (r *repository) From(query interface{}, alias string) Repository {
+	return r.Clone().Search().From(query, alias).db
+}
+
+// Union combine current query with queries using UNION, order, limit and offset of current query apply to the combined result,
+// they shouldn't be used in queries to combine
+//     db.Where("age > ?", 60).Union(db.Model(&User{}).Where("age < ?", 18)).Order("age").Limit(10).Find(&users)
+//     // SELECT * FROM (SELECT * FROM "users" WHERE (age > 60) UNION SELECT * FROM "users" WHERE (age < 18)) AS "users" ORDER BY "age" LIMIT 10
+func (r *repository) Union(queries ...Repository) Repository {
+	return r.Clone().Search().SetOperation("UNION", queries...).db
+}
+
+// UnionAll combine current query with queries using UNION ALL, refer `Union`
+func (r *repository) UnionAll(queries ...Repository) Repository {
+	return r.Clone().Search().SetOperation("UNION ALL", queries...).db
+}
+
+// Intersect combine current query with queries using INTERSECT, refer `Union`,
+// it is not supported by MySQL before 8.0.31 and MariaDB before 10.3
+func (r *repository) Intersect(queries ...Repository) Repository {
+	return r.Clone().Search().SetOperation("INTERSECT", queries...).db
+}
+
+// Except combine current query with queries using EXCEPT, refer `Union`,
+// it is not supported by MySQL before 8.0.31 and MariaDB before 10.3
+func (r *repository) Except(queries ...Repository) Repository {
+	return r.Clone().Search().SetOperation("EXCEPT", queries...).db
+}
+
+// With add a common table expression to the query, query could be a `Repository` or `*Expression`, name could contain columns
+//     recent := db.Model(&Order{}).Where("created_at > ?", lastWeek)
+//     db.With("recent_orders", recent).Table("recent_orders").Where("amount > ?", 100).Find(&orders)
+//     // WITH "recent_orders" AS (SELECT * FROM "orders" WHERE (created_at > ...)) SELECT * FROM "recent_orders" WHERE (amount > 100)
+func (r *repository) With(name string, query interface{}) Repository {
+	return r.Clone().Search().With(name, query, false).db
+}
+
+// WithRecursive add a recursive common table expression to the query, refer `With`
+//     db.WithRecursive("nums(n)", gorm.Expr("SELECT 1 UNION ALL SELECT n + 1 FROM nums WHERE n < ?", 10)).Table("nums").Pluck("n", &nums)
+func (r *repository) WithRecursive(name string, query interface{}) Repository {
+	return r.Clone().Search().With(name, query, true).db
+}
+
+// WithContext set the context for following operations, it is used to carry comment tags (refer `WithComment`)
+//     db.WithContext(ctx).Find(&users)
+func (r *repository) WithContext(ctx context.Context) Repository {
+	return r.Set("gorm:context", ctx)
+}
+
 // Debug start debug mode
 func (r *repository) Debug() Repository {
 	return r.Clone().LogMode(true)
@@ -596,11 +823,15 @@ func (r *repository) Debug() Repository {
 func (r *repository) Begin() Repository {
 	c := r.Clone()
 	if db, ok := c.SQLCommonDB().(sqlDb); ok && db != nil {
+		t := NowFunc()
 		tx, err := db.Begin()
+		recordTransactionMetric(c, "begin", t, err)
 		c.SetSQLCommonDB(interface{}(tx).(SQLCommon))
 
 		c.Dialect().SetDB(c.SQLCommonDB())
-		c.AddError(err)
+		if c.AddError(err) == nil {
+			startTransactionSpan(c)
+		}
 	} else {
 		c.AddError(ErrCantStartTransaction)
 	}
@@ -611,7 +842,11 @@ func (r *repository) Begin() Repository {
 func (r *repository) Commit() Repository {
 	var emptySQLTx *sql.Tx
 	if db, ok := r.db.(sqlTx); ok && db != nil && db != emptySQLTx {
-		r.AddError(db.Commit())
+		t := NowFunc()
+		err := db.Commit()
+		recordTransactionMetric(r, "commit", t, err)
+		endTransactionSpan(r, "commit", err)
+		r.AddError(err)
 	} else {
 		r.AddError(ErrInvalidTransaction)
 	}
@@ -622,7 +857,11 @@ func (r *repository) Commit() Repository {
 func (r *repository) Rollback() Repository {
 	var emptySQLTx *sql.Tx
 	if db, ok := r.db.(sqlTx); ok && db != nil && db != emptySQLTx {
-		r.AddError(db.Rollback())
+		t := NowFunc()
+		err := db.Rollback()
+		recordTransactionMetric(r, "rollback", t, err)
+		endTransactionSpan(r, "rollback", err)
+		r.AddError(err)
 	} else {
 		r.AddError(ErrInvalidTransaction)
 	}
@@ -783,6 +1022,24 @@ func (r *repository) Preload(column string, conditions ...interface{}) Repositor
 	return r.Clone().Search().Preload(column, conditions...).db
 }
 
+// JoinsPreload preload belongs_to or has_one association with LEFT JOIN in the same query, the joined table is aliased with association's name,
+// so conditions on it should be qualified, columns of the main table should be qualified too if they are ambiguous
+//     db.JoinsPreload("Company").Where(`"Company".name = ?`, "jinzhu").Find(&users)
+func (r *repository) JoinsPreload(column string) Repository {
+	return r.Clone().Search().JoinsPreload(column).db
+}
+
+// PreloadCount count has_many or many_to_many associations with a GROUP BY query instead of loading them,
+// counts are set to the integer field named with the association's name and `Count` suffix
+//     type Post struct {
+//       Comments      []Comment
+//       CommentsCount int `gorm:"-"`
+//     }
+//     db.PreloadCount("Comments", "approved = ?", true).Find(&posts)
+func (r *repository) PreloadCount(column string, conditions ...interface{}) Repository {
+	return r.Clone().Search().PreloadCount(column, conditions...).db
+}
+
 // Set set setting by name, which could be used in callbacks, will clone a new db, and update its setting
 func (r *repository) Set(name string, value interface{}) Repository {
 	return r.Clone().InstantSet(name, value)
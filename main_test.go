@@ -716,6 +716,14 @@ func DialectHasTzSupport() bool {
 	return true
 }
 
+// DialectSupports check if the server supports the feature, e.g: MySQL before 8.0 doesn't support `window_function`
+func DialectSupports(feature string) bool {
+	if dialect, ok := DB.Dialect().(interface{ SupportsFeature(string) bool }); ok {
+		return dialect.SupportsFeature(feature)
+	}
+	return true
+}
+
 func TestTimeWithZone(t *testing.T) {
 	var format = "2006-01-02 15:04:05 -0700"
 	var times []time.Time
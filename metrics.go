@@ -0,0 +1,262 @@
+package gorm
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MetricsRecorder receives a Metric after every executed statement and transaction operation, enable it with:
+//     db = db.Set("gorm:metrics_recorder", gorm.NewHistogramRecorder())
+type MetricsRecorder interface {
+	Record(metric Metric)
+}
+
+// Metric describe an executed statement or transaction operation
+type Metric struct {
+	// Operation create, query, update, delete, raw for statements, begin, commit, rollback for transactions
+	Operation    string
+	Table        string
+	Duration     time.Duration
+	RowsAffected int64
+	// ErrorClass blank if no error happened, refer `ErrorClass`
+	ErrorClass string
+}
+
+// errorClasses classes of known errors, wrapped errors are matched with `errors.Is`
+var errorClasses = []struct {
+	class  string
+	errors []error
+}{
+	{"not_found", []error{ErrRecordNotFound, sql.ErrNoRows}},
+	{"invalid_sql", []error{ErrInvalidSQL}},
+	{"transaction", []error{ErrInvalidTransaction, ErrCantStartTransaction, sql.ErrTxDone}},
+	{"unaddressable", []error{ErrUnaddressable}},
+	{"connection", []error{sql.ErrConnDone, driver.ErrBadConn}},
+	{"timeout", []error{context.DeadlineExceeded}},
+	{"canceled", []error{context.Canceled}},
+}
+
+// ErrorClass return a low cardinality class for the error, used to label metrics
+func ErrorClass(err error) string {
+	if errs, ok := err.(Errors); ok && len(errs) > 0 {
+		err = errs[len(errs)-1]
+	}
+
+	if err == nil {
+		return ""
+	}
+
+	for _, errorClass := range errorClasses {
+		for _, target := range errorClass.errors {
+			if errors.Is(err, target) {
+				return errorClass.class
+			}
+		}
+	}
+	return "database"
+}
+
+func metricsRecorder(db Repository) MetricsRecorder {
+	if db != nil {
+		if recorder, ok := db.Get("gorm:metrics_recorder"); ok {
+			if recorder, ok := recorder.(MetricsRecorder); ok {
+				return recorder
+			}
+		}
+	}
+	return nil
+}
+
+// operation return current statement's operation for metrics, statements not issued by CRUD callbacks are `raw`
+func (scope *Scope) operation() string {
+	if operation, ok := scope.InstanceGet("gorm:operation"); ok && !scope.Search.raw {
+		return fmt.Sprint(operation)
+	}
+	return "raw"
+}
+
+func (scope *Scope) recordMetric(t time.Time) {
+	if recorder := metricsRecorder(scope.db); recorder != nil {
+		metric := Metric{
+			Operation:    scope.operation(),
+			Duration:     NowFunc().Sub(t),
+			RowsAffected: scope.db.RowsAffected(),
+			ErrorClass:   ErrorClass(scope.db.Error()),
+		}
+		if metric.Operation != "raw" {
+			metric.Table = scope.TableName()
+		}
+		recorder.Record(metric)
+	}
+}
+
+func recordTransactionMetric(db Repository, operation string, t time.Time, err error) {
+	if recorder := metricsRecorder(db); recorder != nil {
+		recorder.Record(Metric{Operation: operation, Duration: NowFunc().Sub(t), ErrorClass: ErrorClass(err)})
+	}
+}
+
+// DefaultDurationBuckets default upper bounds of HistogramRecorder's duration buckets, in seconds
+var DefaultDurationBuckets = []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// HistogramRecorder in-process MetricsRecorder, collects duration histograms, rows and errors per operation and table.
+// It could be scraped with Prometheus text exposition format as a http.Handler, or published with expvar:
+//     recorder := gorm.NewHistogramRecorder()
+//     http.Handle("/metrics", recorder)
+//     expvar.Publish("gorm", recorder)
+type HistogramRecorder struct {
+	buckets []float64
+	series  map[metricKey]*histogramSeries
+	l       sync.Mutex
+}
+
+type metricKey struct {
+	Operation string
+	Table     string
+}
+
+type histogramSeries struct {
+	Count   int64            `json:"count"`
+	Sum     float64          `json:"sum"`
+	Rows    int64            `json:"rows"`
+	Buckets []int64          `json:"buckets"`
+	Errors  map[string]int64 `json:"errors,omitempty"`
+}
+
+// NewHistogramRecorder create a HistogramRecorder with given bucket upper bounds in seconds, use DefaultDurationBuckets if none given
+func NewHistogramRecorder(buckets ...float64) *HistogramRecorder {
+	if len(buckets) == 0 {
+		buckets = DefaultDurationBuckets
+	}
+	buckets = append([]float64{}, buckets...)
+	sort.Float64s(buckets)
+	return &HistogramRecorder{buckets: buckets, series: map[metricKey]*histogramSeries{}}
+}
+
+// Record implements MetricsRecorder
+func (h *HistogramRecorder) Record(metric Metric) {
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	key := metricKey{Operation: metric.Operation, Table: metric.Table}
+	series, ok := h.series[key]
+	if !ok {
+		series = &histogramSeries{Buckets: make([]int64, len(h.buckets)), Errors: map[string]int64{}}
+		h.series[key] = series
+	}
+
+	seconds := metric.Duration.Seconds()
+	series.Count++
+	series.Sum += seconds
+	series.Rows += metric.RowsAffected
+	for idx, bound := range h.buckets {
+		if seconds <= bound {
+			series.Buckets[idx]++
+		}
+	}
+	if metric.ErrorClass != "" {
+		series.Errors[metric.ErrorClass]++
+	}
+}
+
+func (h *HistogramRecorder) sortedKeys() (keys []metricKey) {
+	for key := range h.series {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Operation != keys[j].Operation {
+			return keys[i].Operation < keys[j].Operation
+		}
+		return keys[i].Table < keys[j].Table
+	})
+	return
+}
+
+// String implements expvar.Var, return collected metrics as JSON
+func (h *HistogramRecorder) String() string {
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	type exportedSeries struct {
+		Operation string `json:"operation"`
+		Table     string `json:"table"`
+		*histogramSeries
+	}
+
+	result := struct {
+		Buckets []float64        `json:"buckets"`
+		Series  []exportedSeries `json:"series"`
+	}{Buckets: h.buckets, Series: []exportedSeries{}}
+
+	for _, key := range h.sortedKeys() {
+		result.Series = append(result.Series, exportedSeries{Operation: key.Operation, Table: key.Table, histogramSeries: h.series[key]})
+	}
+
+	b, _ := json.Marshal(result)
+	return string(b)
+}
+
+// WriteTo write collected metrics with Prometheus text exposition format
+func (h *HistogramRecorder) WriteTo(w io.Writer) (int64, error) {
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	var (
+		buf  strings.Builder
+		keys = h.sortedKeys()
+	)
+
+	buf.WriteString("# HELP gorm_statement_duration_seconds Duration of database operations.\n")
+	buf.WriteString("# TYPE gorm_statement_duration_seconds histogram\n")
+	for _, key := range keys {
+		series, labels := h.series[key], metricLabels(key)
+		for idx, bound := range h.buckets {
+			fmt.Fprintf(&buf, "gorm_statement_duration_seconds_bucket{%v,le=\"%v\"} %d\n", labels, bound, series.Buckets[idx])
+		}
+		fmt.Fprintf(&buf, "gorm_statement_duration_seconds_bucket{%v,le=\"+Inf\"} %d\n", labels, series.Count)
+		fmt.Fprintf(&buf, "gorm_statement_duration_seconds_sum{%v} %v\n", labels, series.Sum)
+		fmt.Fprintf(&buf, "gorm_statement_duration_seconds_count{%v} %d\n", labels, series.Count)
+	}
+
+	buf.WriteString("# HELP gorm_statement_rows_total Rows affected or returned by database operations.\n")
+	buf.WriteString("# TYPE gorm_statement_rows_total counter\n")
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "gorm_statement_rows_total{%v} %d\n", metricLabels(key), h.series[key].Rows)
+	}
+
+	buf.WriteString("# HELP gorm_statement_errors_total Failed database operations by error class.\n")
+	buf.WriteString("# TYPE gorm_statement_errors_total counter\n")
+	for _, key := range keys {
+		var classes []string
+		for class := range h.series[key].Errors {
+			classes = append(classes, class)
+		}
+		sort.Strings(classes)
+		for _, class := range classes {
+			fmt.Fprintf(&buf, "gorm_statement_errors_total{%v,class=%q} %d\n", metricLabels(key), class, h.series[key].Errors[class])
+		}
+	}
+
+	n, err := io.WriteString(w, buf.String())
+	return int64(n), err
+}
+
+// ServeHTTP implements http.Handler, serve collected metrics with Prometheus text exposition format
+func (h *HistogramRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	h.WriteTo(w)
+}
+
+func metricLabels(key metricKey) string {
+	return fmt.Sprintf("operation=%q,table=%q", key.Operation, key.Table)
+}
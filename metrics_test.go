@@ -0,0 +1,108 @@
+package gorm_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zhinanxing/gorm/v3"
+)
+
+type metricsCollector struct {
+	metrics []gorm.Metric
+}
+
+func (collector *metricsCollector) Record(metric gorm.Metric) {
+	collector.metrics = append(collector.metrics, metric)
+}
+
+func (collector *metricsCollector) operations() (operations []string) {
+	for _, metric := range collector.metrics {
+		operations = append(operations, metric.Operation+":"+metric.Table)
+	}
+	return
+}
+
+func TestMetricsRecorder(t *testing.T) {
+	collector := &metricsCollector{}
+	db := DB.Set("gorm:metrics_recorder", collector)
+
+	company := Company{Name: "metrics"}
+	db.Save(&company)
+	db.Model(&company).Update("name", "metrics_updated")
+	db.Where("name = ?", "metrics_updated").Find(&[]Company{})
+	db.Exec("UPDATE companies SET name = ? WHERE name = ?", "metrics", "metrics_updated")
+	db.Delete(&company)
+	db.First(&Company{}, company.Id)
+
+	expected := []string{"begin:", "create:companies", "commit:", "begin:", "update:companies", "commit:", "query:companies", "raw:", "begin:", "delete:companies", "commit:", "query:companies"}
+	if got := collector.operations(); strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("Should record metrics for all operations, expected %v, but got %v", expected, got)
+	}
+
+	if metric := collector.metrics[1]; metric.RowsAffected != 1 || metric.ErrorClass != "" {
+		t.Errorf("Should record rows affected for create, but got %#v", metric)
+	}
+
+	if metric := collector.metrics[len(collector.metrics)-1]; metric.ErrorClass != "not_found" {
+		t.Errorf("Should record error class for not found record, but got %#v", metric)
+	}
+
+	collector.metrics = nil
+	db.Transaction(func(tx gorm.Repository) error {
+		return tx.Create(&Company{Name: "metrics_tx"}).Error()
+	})
+	if got := collector.operations(); len(got) == 0 || got[0] != "begin:" || got[len(got)-1] != "commit:" {
+		t.Errorf("Should record metrics for transaction, but got %v", got)
+	}
+}
+
+func TestErrorClass(t *testing.T) {
+	for _, test := range []struct {
+		err   error
+		class string
+	}{
+		{nil, ""},
+		{gorm.ErrRecordNotFound, "not_found"},
+		{fmt.Errorf("loading user: %w", gorm.ErrRecordNotFound), "not_found"},
+		{gorm.Errors{errors.New("first"), fmt.Errorf("wrapped: %w", context.DeadlineExceeded)}, "timeout"},
+		{errors.New("duplicated key"), "database"},
+	} {
+		if class := gorm.ErrorClass(test.err); class != test.class {
+			t.Errorf("Error class of %v should be %v, but got %v", test.err, test.class, class)
+		}
+	}
+}
+
+func TestHistogramRecorder(t *testing.T) {
+	recorder := gorm.NewHistogramRecorder()
+	db := DB.Set("gorm:metrics_recorder", recorder)
+
+	db.Save(&Company{Name: "histogram"})
+	db.Where("name = ?", "histogram").Find(&[]Company{})
+	db.Where("name = ?", "no such company").First(&Company{})
+
+	response := httptest.NewRecorder()
+	recorder.ServeHTTP(response, httptest.NewRequest("GET", "/metrics", nil))
+	body := response.Body.String()
+
+	for _, line := range []string{
+		`gorm_statement_duration_seconds_count{operation="query",table="companies"} 2`,
+		`gorm_statement_duration_seconds_bucket{operation="create",table="companies",le="+Inf"} 1`,
+		`gorm_statement_rows_total{operation="create",table="companies"} 1`,
+		`gorm_statement_errors_total{operation="query",table="companies",class="not_found"} 1`,
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("Should expose %v, but got %v", line, body)
+		}
+	}
+
+	var exported map[string]interface{}
+	if err := json.Unmarshal([]byte(recorder.String()), &exported); err != nil || len(exported["series"].([]interface{})) == 0 {
+		t.Errorf("Should export metrics as JSON for expvar, but got %v, %v", recorder.String(), err)
+	}
+}
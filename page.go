@@ -0,0 +1,46 @@
+package gorm
+
+import (
+	"fmt"
+)
+
+// PageInfo pagination metadata returned by `Page`
+type PageInfo struct {
+	Page    int
+	Size    int
+	Total   int64
+	Pages   int
+	HasNext bool
+}
+
+func (scope *Scope) page(page, size int) (PageInfo, error) {
+	info := PageInfo{Page: page, Size: size}
+	if page < 1 || size < 1 {
+		return info, scope.Err(fmt.Errorf("invalid page %v with page size %v", page, size))
+	}
+
+	// count with the same conditions, joins, group and having, but without order, preload and limit, the select is
+	// only kept for grouped queries, as having conditions could reference its aliases
+	countScope := scope.db.NewScope(scope.Value)
+	countScope.Search.orders, countScope.Search.preload = nil, nil
+	countScope.Search.limit, countScope.Search.offset = nil, nil
+	if len(countScope.Search.group) == 0 {
+		countScope.Search.selects = map[string]interface{}{}
+		countScope.count(&info.Total)
+	} else {
+		countScope.Search.ignoreOrderQuery = true
+		countScope.countSubQuery(&info.Total)
+	}
+
+	if err := countScope.db.Error(); err != nil {
+		return info, scope.Err(err)
+	}
+
+	info.Pages = int((info.Total + int64(size) - 1) / int64(size))
+	info.HasNext = page < info.Pages
+
+	if err := scope.db.Limit(size).Offset((page - 1) * size).Find(scope.Value).Error(); err != nil {
+		return info, scope.Err(err)
+	}
+	return info, nil
+}
@@ -0,0 +1,93 @@
+package gorm_test
+
+import (
+	"testing"
+)
+
+func TestPage(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		DB.Save(&User{Name: "PageUser", Age: int64(i % 3), Emails: []Email{{Email: "page@example.org"}}})
+	}
+
+	var users []User
+	info, err := DB.Where("name = ?", "PageUser").Preload("Emails").Select("id, name, age").Order("age desc, id").Page(&users, 2, 2)
+	if err != nil {
+		t.Fatalf("No error should happen when page, but got %v", err)
+	}
+
+	if info.Total != 5 || info.Pages != 3 || !info.HasNext || info.Page != 2 || info.Size != 2 {
+		t.Errorf("Page info is not correct, got %#v", info)
+	}
+
+	if len(users) != 2 || users[0].Age != 1 || len(users[0].Emails) != 1 {
+		t.Errorf("Should find users of the second page with preloaded emails, but got %#v", users)
+	}
+
+	info, _ = DB.Where("name = ?", "PageUser").Page(&users, 3, 2)
+	if info.HasNext || len(users) != 1 {
+		t.Errorf("Last page should have no next page, but got %#v with %v users", info, len(users))
+	}
+
+	info, _ = DB.Where("name = ?", "PageUser").Page(&users, 4, 2)
+	if info.Total != 5 || len(users) != 0 {
+		t.Errorf("Page out of range should be empty, but got %#v with %v users", info, len(users))
+	}
+
+	if _, err := DB.Page(&users, 0, 2); err == nil {
+		t.Errorf("Should return error for invalid page")
+	}
+}
+
+func TestPageWithGroupAndJoins(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		DB.Save(&User{Name: "PageGroupUser", Age: int64(i % 3), Emails: []Email{{Email: "a@example.org"}, {Email: "b@example.org"}}})
+	}
+
+	type result struct {
+		Age   int64
+		Total int64
+	}
+
+	var results []result
+	info, err := DB.Table("users").Select("age, count(*) as total").Where("name = ?", "PageGroupUser").
+		Group("age").Having("count(*) > ?", 1).Order("age").Page(&results, 1, 1)
+	if err != nil {
+		t.Fatalf("No error should happen when page grouped query, but got %v", err)
+	}
+
+	if info.Total != 2 || info.Pages != 2 || !info.HasNext {
+		t.Errorf("Should count groups matching having conditions, but got %#v", info)
+	}
+
+	if len(results) != 1 || results[0].Age != 0 || results[0].Total != 2 {
+		t.Errorf("Should find the first group, but got %#v", results)
+	}
+
+	var count int
+	DB.Table("users").Where("name = ?", "PageGroupUser").Group("age").Having("count(*) > ?", 1).Count(&count)
+	if count != 2 {
+		t.Errorf("Count should respect having conditions, but got %v", count)
+	}
+
+	// postgres and mssql don't accept aliases of the select in having conditions
+	if dialect := DB.Dialect().GetName(); dialect != "postgres" && dialect != "mssql" {
+		scopedDB := DB.Table("users").Select("age, count(*) AS total").Where("name = ?", "PageGroupUser").Group("age").Having("total > ?", 1)
+		if err := scopedDB.Count(&count).Error(); err != nil || count != 2 {
+			t.Errorf("Count should keep the select for having conditions, but got %v, %v", count, err)
+		}
+
+		if info, err := scopedDB.Order("age").Page(&results, 1, 1); err != nil || info.Total != 2 || len(results) != 1 {
+			t.Errorf("Page should keep the select for having conditions, but got %#v, %v", info, err)
+		}
+	}
+
+	var users []User
+	info, err = DB.Joins("JOIN emails ON emails.user_id = users.id").Where("users.name = ?", "PageGroupUser").Page(&users, 1, 20)
+	if err != nil {
+		t.Fatalf("No error should happen when page joined query, but got %v", err)
+	}
+
+	if info.Total != 10 || len(users) != 10 || info.HasNext {
+		t.Errorf("Count should match joined rows, but got %#v with %v users", info, len(users))
+	}
+}
@@ -0,0 +1,243 @@
+package gorm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// KeysetPage contains cursors of a page loaded with `Paginate`, cursors are blank if there is no next/prev page
+type KeysetPage struct {
+	NextCursor string
+	PrevCursor string
+	HasNext    bool
+	HasPrev    bool
+}
+
+// keysetCursor is encoded as the opaque cursor token, it contains order keys of the first/last row of a page
+type keysetCursor struct {
+	Keys     []json.RawMessage `json:"k"`
+	Backward bool              `json:"b,omitempty"`
+}
+
+type keysetOrder struct {
+	field *Field
+	desc  bool
+}
+
+// supportRowValues dialects support row value comparison like `(a, b) > (?, ?)`
+var supportRowValues = map[string]bool{"mysql": true, "postgres": true, "sqlite3": true}
+
+func (scope *Scope) keysetOrders(orderFields []string) ([]keysetOrder, error) {
+	var orders []keysetOrder
+	for _, orderField := range orderFields {
+		parts := strings.Fields(orderField)
+		if len(parts) == 0 || len(parts) > 2 {
+			return nil, fmt.Errorf("invalid order field %v", orderField)
+		}
+
+		field, ok := scope.FieldByName(parts[0])
+		if !ok || !field.IsNormal {
+			return nil, fmt.Errorf("invalid order field %v", orderField)
+		}
+
+		// rows with NULL never match keyset conditions like `age > ?`, they would be skipped silently
+		if _, notNull := field.TagSettings["NOT NULL"]; !notNull && (field.Struct.Type.Kind() == reflect.Ptr || field.IsScanner) {
+			return nil, fmt.Errorf("order field %v is nullable, tag it with `not null` to paginate by it", orderField)
+		}
+
+		order := keysetOrder{field: field}
+		if len(parts) == 2 {
+			switch strings.ToUpper(parts[1]) {
+			case "DESC":
+				order.desc = true
+			case "ASC":
+			default:
+				return nil, fmt.Errorf("invalid order field %v", orderField)
+			}
+		}
+		orders = append(orders, order)
+	}
+
+	// append primary key to make sure the order is unique
+	if primaryField := scope.PrimaryField(); primaryField != nil {
+		var hasPrimaryKey bool
+		for _, order := range orders {
+			if order.field.DBName == primaryField.DBName {
+				hasPrimaryKey = true
+			}
+		}
+
+		if !hasPrimaryKey {
+			order := keysetOrder{field: primaryField}
+			if len(orders) > 0 {
+				order.desc = orders[len(orders)-1].desc
+			}
+			orders = append(orders, order)
+		}
+	}
+
+	if len(orders) == 0 {
+		return nil, errors.New("paginate requires order fields or a primary key")
+	}
+	return orders, nil
+}
+
+// seekCondition build the condition to seek rows after given keys, use `(a, b) > (?, ?)` if possible,
+// otherwise the expanded form `a > ? OR (a = ? AND b > ?)`
+func (scope *Scope) seekCondition(orders []keysetOrder, keys []interface{}, backward bool) (string, []interface{}) {
+	var (
+		columns  []string
+		sameDesc = true
+	)
+
+	for _, order := range orders {
+		columns = append(columns, fmt.Sprintf("%v.%v", scope.QuotedTableName(), scope.Quote(order.field.DBName)))
+		sameDesc = sameDesc && order.desc == orders[0].desc
+	}
+
+	operator := func(order keysetOrder) string {
+		if order.desc != backward {
+			return "<"
+		}
+		return ">"
+	}
+
+	if sameDesc && supportRowValues[scope.Dialect().GetName()] {
+		marks := strings.TrimSuffix(strings.Repeat("?,", len(keys)), ",")
+		return fmt.Sprintf("(%v) %v (%v)", strings.Join(columns, ","), operator(orders[0]), marks), keys
+	}
+
+	var (
+		conditions []string
+		values     []interface{}
+	)
+
+	for idx, order := range orders {
+		var parts []string
+		for i := 0; i < idx; i++ {
+			parts = append(parts, fmt.Sprintf("%v = ?", columns[i]))
+			values = append(values, keys[i])
+		}
+		parts = append(parts, fmt.Sprintf("%v %v ?", columns[idx], operator(order)))
+		values = append(values, keys[idx])
+		conditions = append(conditions, "("+strings.Join(parts, " AND ")+")")
+	}
+	return strings.Join(conditions, " OR "), values
+}
+
+func (scope *Scope) encodeKeysetCursor(orders []keysetOrder, row reflect.Value, backward bool) (string, error) {
+	cursor := keysetCursor{Backward: backward}
+	rowScope := scope.New(row.Addr().Interface())
+	for _, order := range orders {
+		field, _ := rowScope.FieldByName(order.field.Name)
+		key, err := json.Marshal(field.Field.Interface())
+		if err != nil {
+			return "", err
+		}
+		cursor.Keys = append(cursor.Keys, key)
+	}
+
+	b, err := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(b), err
+}
+
+func decodeKeysetCursor(orders []keysetOrder, str string) (keys []interface{}, backward bool, err error) {
+	var cursor keysetCursor
+	b, err := base64.RawURLEncoding.DecodeString(str)
+	if err == nil {
+		err = json.Unmarshal(b, &cursor)
+	}
+
+	if err != nil || len(cursor.Keys) != len(orders) {
+		return nil, false, ErrInvalidCursor
+	}
+
+	for idx, order := range orders {
+		key := reflect.New(order.field.Struct.Type)
+		if err := json.Unmarshal(cursor.Keys[idx], key.Interface()); err != nil {
+			return nil, false, ErrInvalidCursor
+		}
+		keys = append(keys, key.Elem().Interface())
+	}
+	return keys, cursor.Backward, nil
+}
+
+func (scope *Scope) paginate(cursor string, pageSize int, orderFields []string) (*KeysetPage, error) {
+	results := indirect(reflect.ValueOf(scope.Value))
+	if results.Kind() != reflect.Slice {
+		return nil, scope.Err(fmt.Errorf("results should be a slice, not %s", results.Kind()))
+	}
+
+	if pageSize <= 0 {
+		return nil, scope.Err(fmt.Errorf("invalid page size %v", pageSize))
+	}
+
+	orders, err := scope.keysetOrders(orderFields)
+	if err != nil {
+		return nil, scope.Err(err)
+	}
+
+	var (
+		db       = scope.db
+		keys     []interface{}
+		backward bool
+	)
+
+	if cursor != "" {
+		if keys, backward, err = decodeKeysetCursor(orders, cursor); err != nil {
+			return nil, scope.Err(err)
+		}
+		sql, values := scope.seekCondition(orders, keys, backward)
+		db = db.Where(sql, values...)
+	}
+
+	for idx, order := range orders {
+		direction := "ASC"
+		if order.desc != backward {
+			direction = "DESC"
+		}
+		db = db.Order(fmt.Sprintf("%v.%v %v", scope.QuotedTableName(), scope.Quote(order.field.DBName), direction), idx == 0)
+	}
+
+	if err := db.Limit(pageSize + 1).Find(scope.Value).Error(); err != nil {
+		return nil, scope.Err(err)
+	}
+
+	hasMore := results.Len() > pageSize
+	if hasMore {
+		results.Set(results.Slice(0, pageSize))
+	}
+
+	if backward {
+		for i, j := 0, results.Len()-1; i < j; i, j = i+1, j-1 {
+			tmp := reflect.ValueOf(results.Index(i).Interface())
+			results.Index(i).Set(results.Index(j))
+			results.Index(j).Set(tmp)
+		}
+	}
+
+	page := &KeysetPage{HasNext: hasMore, HasPrev: cursor != ""}
+	if backward {
+		page.HasNext, page.HasPrev = true, hasMore
+	}
+
+	if results.Len() > 0 {
+		if page.HasNext {
+			if page.NextCursor, err = scope.encodeKeysetCursor(orders, indirect(results.Index(results.Len()-1)), false); err != nil {
+				return nil, scope.Err(err)
+			}
+		}
+
+		if page.HasPrev {
+			if page.PrevCursor, err = scope.encodeKeysetCursor(orders, indirect(results.Index(0)), true); err != nil {
+				return nil, scope.Err(err)
+			}
+		}
+	}
+
+	return page, nil
+}
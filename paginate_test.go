@@ -0,0 +1,124 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/zhinanxing/gorm/v3"
+)
+
+func TestPaginate(t *testing.T) {
+	for i := 0; i < 7; i++ {
+		DB.Save(&User{Name: "PaginateUser", Age: int64(i % 3)})
+	}
+
+	var (
+		scopedDB = DB.Where("name = ?", "PaginateUser")
+		all      []User
+		pages    [][]User
+		cursor   string
+	)
+
+	scopedDB.Order("age desc, id desc").Find(&all)
+	for {
+		var users []User
+		page, err := scopedDB.Paginate(&users, cursor, 3, "age DESC")
+		if err != nil {
+			t.Fatalf("No error should happen when paginate, but got %v", err)
+		}
+
+		if (cursor == "") == page.HasPrev {
+			t.Errorf("Only first page has no prev page, but got %#v", page)
+		}
+
+		pages = append(pages, users)
+		if !page.HasNext {
+			if page.NextCursor != "" {
+				t.Errorf("Next cursor should be blank for last page")
+			}
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	if len(pages) != 3 || len(pages[0]) != 3 || len(pages[2]) != 1 {
+		t.Fatalf("Should get 3 pages, but got %v", len(pages))
+	}
+
+	var idx int
+	for _, users := range pages {
+		for _, user := range users {
+			if user.Id != all[idx].Id {
+				t.Errorf("Paginated records should be ordered by age desc, id desc, but got %v at %v", user.Id, idx)
+			}
+			idx++
+		}
+	}
+
+	// go back from the last page
+	var lastPage []User
+	page, _ := scopedDB.Paginate(&lastPage, cursor, 3, "age DESC")
+
+	var users []User
+	page, err := scopedDB.Paginate(&users, page.PrevCursor, 3, "age DESC")
+	if err != nil {
+		t.Fatalf("No error should happen when paginate backward, but got %v", err)
+	}
+
+	if len(users) != 3 || users[0].Id != pages[1][0].Id || users[2].Id != pages[1][2].Id {
+		t.Errorf("Should get the second page when paginate backward, but got %v", users)
+	}
+
+	if !page.HasNext || !page.HasPrev || page.NextCursor == "" || page.PrevCursor == "" {
+		t.Errorf("Second page should have next and prev pages, but got %#v", page)
+	}
+
+	if _, err := scopedDB.Paginate(&users, "invalid", 3, "age DESC"); err != gorm.ErrInvalidCursor {
+		t.Errorf("Should return ErrInvalidCursor for invalid cursor, but got %v", err)
+	}
+
+	if _, err := scopedDB.Paginate(&users, "", 3, "birthday DESC"); err == nil {
+		t.Errorf("Should reject nullable order fields")
+	}
+}
+
+func TestPaginateWithMixedOrders(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		DB.Save(&User{Name: "PaginateMixedUser", Age: int64(i % 2)})
+	}
+
+	var (
+		scopedDB = DB.Where("name = ?", "PaginateMixedUser")
+		all      []User
+		ids      []int64
+		cursor   string
+	)
+
+	scopedDB.Order("age desc, id asc").Find(&all)
+	for {
+		var users []User
+		page, err := scopedDB.Paginate(&users, cursor, 2, "age DESC", "id ASC")
+		if err != nil {
+			t.Fatalf("No error should happen when paginate, but got %v", err)
+		}
+
+		for _, user := range users {
+			ids = append(ids, user.Id)
+		}
+
+		if !page.HasNext {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	if len(ids) != len(all) {
+		t.Fatalf("Should paginate all records, expect %v, but got %v", len(all), len(ids))
+	}
+
+	for idx, user := range all {
+		if ids[idx] != user.Id {
+			t.Errorf("Paginated records should be ordered by age desc, id asc, but got %v", ids)
+			break
+		}
+	}
+}
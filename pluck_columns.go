@@ -0,0 +1,138 @@
+package gorm
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// pluckColumns query columns into a slice of structs matched by column name or alias, or into a `[][]interface{}`
+func (scope *Scope) pluckColumns(value interface{}, columns ...string) *Scope {
+	dest := reflect.Indirect(reflect.ValueOf(value))
+	if dest.Kind() != reflect.Slice {
+		scope.Err(fmt.Errorf("results should be a slice, not %s", dest.Kind()))
+		return scope
+	}
+
+	var (
+		elemType = dest.Type().Elem()
+		isPtr    = elemType.Kind() == reflect.Ptr
+	)
+
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+
+	isTuple := elemType.Kind() == reflect.Slice && elemType.Elem().Kind() == reflect.Interface
+	if !isTuple && elemType.Kind() != reflect.Struct {
+		scope.Err(fmt.Errorf("results should be a slice of structs or [][]interface{}, not %s", dest.Type()))
+		return scope
+	}
+
+	if len(columns) > 0 {
+		if len(scope.Search.setOperations) > 0 {
+			scope.InstanceSet("gorm:set_operation_select", strings.Join(columns, ", "))
+		} else {
+			scope.Search.Select(strings.Join(columns, ", "))
+		}
+	}
+
+	rows, err := scope.rows()
+	if scope.Err(err) != nil {
+		return scope
+	}
+	defer rows.Close()
+
+	names, err := rows.Columns()
+	if scope.Err(err) != nil {
+		return scope
+	}
+
+	columnTypes, _ := rows.ColumnTypes()
+
+	var fieldIndexes [][]int
+	if !isTuple {
+		fieldIndexes = pluckFieldIndexes(elemType, names)
+	}
+
+	dest.Set(reflect.MakeSlice(dest.Type(), 0, 0))
+	for rows.Next() {
+		var (
+			elem    = reflect.New(elemType).Elem()
+			ignored interface{}
+			values  = make([]interface{}, len(names))
+		)
+
+		for index := range names {
+			if isTuple {
+				values[index] = new(interface{})
+			} else if fieldIndexes[index] != nil {
+				values[index] = elem.FieldByIndex(fieldIndexes[index]).Addr().Interface()
+			} else {
+				values[index] = &ignored
+			}
+		}
+
+		if scope.Err(rows.Scan(values...)) != nil {
+			return scope
+		}
+
+		if isTuple {
+			tuple := make([]interface{}, len(names))
+			for index := range names {
+				var databaseType string
+				if index < len(columnTypes) && columnTypes[index] != nil {
+					databaseType = strings.ToUpper(columnTypes[index].DatabaseTypeName())
+				}
+				tuple[index] = normalizeScanMapValue(*(values[index].(*interface{})), databaseType)
+			}
+			elem.Set(reflect.ValueOf(tuple).Convert(elemType))
+		}
+
+		if isPtr {
+			dest.Set(reflect.Append(dest, elem.Addr()))
+		} else {
+			dest.Set(reflect.Append(dest, elem))
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		scope.Err(err)
+	}
+	return scope
+}
+
+// pluckFieldIndexes match columns with exported fields of the struct, by `column` tag, field name or its db name
+func pluckFieldIndexes(structType reflect.Type, columns []string) [][]int {
+	indexes := make([][]int, len(columns))
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tagSettings := parseTagSetting(field.Tag)
+		if _, ok := tagSettings["-"]; ok {
+			continue
+		}
+
+		names := []string{field.Name, ToDBName(field.Name)}
+		if name, ok := tagSettings["COLUMN"]; ok {
+			names = []string{name}
+		}
+
+		for index, column := range columns {
+			if indexes[index] != nil {
+				continue
+			}
+
+			for _, name := range names {
+				if strings.EqualFold(name, column) {
+					indexes[index] = field.Index
+					break
+				}
+			}
+		}
+	}
+	return indexes
+}
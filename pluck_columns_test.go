@@ -0,0 +1,45 @@
+package gorm_test
+
+import (
+	"testing"
+)
+
+func TestPluckColumns(t *testing.T) {
+	user1 := User{Name: "PluckColumnsUser1", Age: 10}
+	user2 := User{Name: "PluckColumnsUser2", Age: 20}
+	DB.Save(&user1).Save(&user2)
+
+	scopedDB := DB.Model(&User{}).Where("name LIKE ?", "PluckColumnsUser%").Order("id")
+
+	var options []struct {
+		Value int64
+		Label string
+	}
+	if err := scopedDB.PluckColumns(&options, "id AS value", "name AS label").Error(); err != nil {
+		t.Fatalf("No error should happen when pluck columns, but got %v", err)
+	}
+
+	if len(options) != 2 || options[0].Value != user1.Id || options[0].Label != user1.Name || options[1].Label != user2.Name {
+		t.Errorf("Should pluck columns into structs by alias, but got %#v", options)
+	}
+
+	var users []*struct {
+		UserName string `gorm:"column:name"`
+		UserAge  int64  `gorm:"column:age"`
+	}
+	scopedDB.Select("name, age").PluckColumns(&users)
+	if len(users) != 2 || users[1].UserName != user2.Name || users[1].UserAge != user2.Age {
+		t.Errorf("Should pluck selected columns into structs by column tag, but got %#v", users)
+	}
+
+	var rows [][]interface{}
+	scopedDB.PluckColumns(&rows, "id", "name")
+	if len(rows) != 2 || len(rows[0]) != 2 || rows[0][1] != user1.Name || rows[1][1] != user2.Name {
+		t.Errorf("Should pluck columns into tuples, but got %#v", rows)
+	}
+
+	var names []string
+	if err := scopedDB.PluckColumns(&names, "name").Error(); err == nil {
+		t.Errorf("Should return error when pluck columns into unsupported results")
+	}
+}
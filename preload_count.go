@@ -0,0 +1,149 @@
+package gorm
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// preloadCounts count has_many and many_to_many associations registered with `PreloadCount`
+func (scope *Scope) preloadCounts() {
+	for _, preload := range scope.Search.preloadCount {
+		if strings.Contains(preload.schema, ".") {
+			scope.Err(fmt.Errorf("can't preload count of nested association %s", preload.schema))
+			return
+		}
+
+		field, ok := scope.FieldByName(preload.schema)
+		if !ok || field.Relationship == nil {
+			scope.Err(fmt.Errorf("can't preload count of field %s for %s", preload.schema, scope.GetModelStruct().ModelType))
+			return
+		}
+
+		scope.handlePreloadCount(field, preload.conditions)
+	}
+}
+
+// handlePreloadCount count associations of every parent with a GROUP BY query, and set counts to field named like `CommentsCount`
+func (scope *Scope) handlePreloadCount(field *Field, conditions []interface{}) {
+	var (
+		relation         = field.Relationship
+		countFieldName   = field.Name + "Count"
+		keyColumns       []string
+		parentFieldNames []string
+	)
+
+	countField, ok := scope.GetModelStruct().ModelType.FieldByName(countFieldName)
+	if ok {
+		switch countField.Type.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			ok = false
+		}
+	}
+
+	if !ok {
+		scope.Err(fmt.Errorf("can't find integer field %s for %s to preload count", countFieldName, scope.GetModelStruct().ModelType))
+		return
+	}
+
+	fieldType := field.Struct.Type.Elem()
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	// preload conditions
+	preloadDB, preloadConditions := scope.generatePreloadDBWithConditions(conditions)
+	newScope := scope.New(reflect.New(fieldType).Interface())
+	preloadDB = preloadDB.Table(newScope.TableName()).Model(newScope.Value)
+
+	switch relation.Kind {
+	case "has_many":
+		primaryKeys := scope.getColumnAsArray(relation.AssociationForeignFieldNames, scope.Value)
+		if len(primaryKeys) == 0 {
+			return
+		}
+
+		var foreignDBNames []string
+		for _, dbName := range relation.ForeignDBNames {
+			foreignDBNames = append(foreignDBNames, newScope.TableName()+"."+dbName)
+			keyColumns = append(keyColumns, fmt.Sprintf("%v.%v", newScope.QuotedTableName(), scope.Quote(dbName)))
+		}
+
+		query := fmt.Sprintf("%v IN (%v)", toQueryCondition(scope, foreignDBNames), toQueryMarks(primaryKeys))
+		values := toQueryValues(primaryKeys)
+		if relation.PolymorphicType != "" {
+			query += fmt.Sprintf(" AND %v.%v = ?", newScope.QuotedTableName(), scope.Quote(relation.PolymorphicDBName))
+			values = append(values, relation.PolymorphicValue)
+		}
+
+		preloadDB = preloadDB.Where(query, values...)
+		parentFieldNames = relation.AssociationForeignFieldNames
+	case "many_to_many":
+		joinTableHandler := relation.JoinTableHandler
+		preloadDB = joinTableHandler.JoinWith(joinTableHandler, preloadDB, scope.Value)
+
+		for _, key := range joinTableHandler.SourceForeignKeys() {
+			keyColumns = append(keyColumns, fmt.Sprintf("%v.%v", scope.Quote(joinTableHandler.Table(preloadDB)), scope.Quote(key.DBName)))
+		}
+
+		for _, dbName := range relation.ForeignFieldNames {
+			if field, ok := scope.FieldByName(dbName); ok {
+				parentFieldNames = append(parentFieldNames, field.Name)
+			}
+		}
+	default:
+		scope.Err(errors.New("only has_many and many_to_many associations could be counted"))
+		return
+	}
+
+	// preload inline conditions
+	if len(preloadConditions) > 0 {
+		preloadDB = preloadDB.Where(preloadConditions[0], preloadConditions[1:]...)
+	}
+
+	groupBy := strings.Join(keyColumns, ",")
+	rows, err := preloadDB.Select(groupBy + ", count(*)").Group(groupBy).Rows()
+	if scope.Err(err) != nil {
+		return
+	}
+	defer rows.Close()
+
+	counts := map[string]int64{}
+	for rows.Next() {
+		var (
+			keys   = make([]interface{}, len(keyColumns))
+			count  int64
+			values []interface{}
+		)
+
+		for idx := range keys {
+			values = append(values, &keys[idx])
+		}
+
+		if scope.Err(rows.Scan(append(values, &count)...)) != nil {
+			return
+		}
+		counts[toString(keys)] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		scope.Err(err)
+	}
+
+	// assign counts, parents without associations get 0
+	assignCount := func(object reflect.Value) {
+		countField := object.FieldByName(countFieldName)
+		count := counts[toString(getValueFromFields(object, parentFieldNames))]
+		countField.Set(reflect.ValueOf(count).Convert(countField.Type()))
+	}
+
+	if indirectScopeValue := scope.IndirectValue(); indirectScopeValue.Kind() == reflect.Slice {
+		for j := 0; j < indirectScopeValue.Len(); j++ {
+			assignCount(indirect(indirectScopeValue.Index(j)))
+		}
+	} else if indirectScopeValue.IsValid() {
+		assignCount(indirectScopeValue)
+	}
+}
@@ -0,0 +1,94 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/zhinanxing/gorm/v3"
+)
+
+type PreloadCountPost struct {
+	Id            int64
+	Title         string
+	Comments      []PreloadCountComment
+	CommentsCount int               `gorm:"-"`
+	Tags          []PreloadCountTag `gorm:"many2many:preload_count_post_tags"`
+	TagsCount     uint              `gorm:"-"`
+}
+
+type PreloadCountComment struct {
+	Id                 int64
+	PreloadCountPostId int64
+	Body               string
+	Approved           bool
+}
+
+type PreloadCountTag struct {
+	Id   int64
+	Name string
+}
+
+func TestPreloadCount(t *testing.T) {
+	DB.DropTableIfExists(&PreloadCountPost{}, &PreloadCountComment{}, &PreloadCountTag{}, "preload_count_post_tags")
+	if err := DB.AutoMigrate(&PreloadCountPost{}, &PreloadCountComment{}, &PreloadCountTag{}).Error(); err != nil {
+		t.Fatalf("Failed to migrate, got error: %v", err)
+	}
+
+	tags := []PreloadCountTag{{Name: "go"}, {Name: "sql"}}
+	posts := []PreloadCountPost{
+		{Title: "post1", Comments: []PreloadCountComment{{Body: "a", Approved: true}, {Body: "b"}, {Body: "c", Approved: true}}, Tags: tags},
+		{Title: "post2", Comments: []PreloadCountComment{{Body: "d"}}},
+		{Title: "post3"},
+	}
+	for i := range posts {
+		DB.Save(&posts[i])
+	}
+
+	var results []PreloadCountPost
+	if err := DB.PreloadCount("Comments").PreloadCount("Tags").Order("id").Find(&results).Error(); err != nil {
+		t.Fatalf("No error should happen when preload count, but got %v", err)
+	}
+
+	if len(results) != 3 || results[0].CommentsCount != 3 || results[1].CommentsCount != 1 || results[2].CommentsCount != 0 {
+		t.Errorf("Should count comments of every post, but got %#v", results)
+	}
+
+	if results[0].TagsCount != 2 || results[1].TagsCount != 0 {
+		t.Errorf("Should count many2many tags of every post, but got %v, %v", results[0].TagsCount, results[1].TagsCount)
+	}
+
+	if len(results[0].Comments) != 0 {
+		t.Errorf("Should not load comments when preload count")
+	}
+
+	var post PreloadCountPost
+	DB.PreloadCount("Comments", "approved = ?", true).First(&post, posts[0].Id)
+	if post.CommentsCount != 2 {
+		t.Errorf("Should count comments with conditions, but got %v", post.CommentsCount)
+	}
+
+	if err := DB.PreloadCount("Title").Find(&results).Error(); err == nil {
+		t.Errorf("Should return error when preload count of a non association field")
+	}
+}
+
+func TestPreloadWithSelectedColumns(t *testing.T) {
+	user := User{Name: "PreloadSelectUser", Emails: []Email{{Email: "select1@example.org"}, {Email: "select2@example.org"}}, Languages: []Language{{Name: "PreloadSelectLanguage"}}}
+	DB.Save(&user)
+
+	var users []User
+	if err := DB.Where("name = ?", user.Name).Preload("Emails", func(db gorm.Repository) gorm.Repository {
+		return db.Select("id, email")
+	}).Preload("Languages", func(db gorm.Repository) gorm.Repository {
+		return db.Select([]string{"id", "name"})
+	}).Find(&users).Error(); err != nil {
+		t.Fatalf("No error should happen when preload with selected columns, but got %v", err)
+	}
+
+	if len(users) != 1 || len(users[0].Emails) != 2 || len(users[0].Languages) != 1 {
+		t.Fatalf("Should preload associations with selected columns, but got %#v", users)
+	}
+
+	if users[0].Emails[0].Email == "" || users[0].Emails[0].UserId != int(user.Id) || users[0].Languages[0].Name != "PreloadSelectLanguage" {
+		t.Errorf("Selected columns and keys should be loaded, but got %#v, %#v", users[0].Emails[0], users[0].Languages[0])
+	}
+}
@@ -0,0 +1,113 @@
+package gorm
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// supportWindowFunctions dialects support `ROW_NUMBER() OVER (PARTITION BY ...)`, mysql depends on the server version
+// (MySQL 8.0, MariaDB 10.2), it could be enabled or disabled with:
+//     db.Set("gorm:preload_window_function", false)
+var supportWindowFunctions = map[string]bool{"postgres": true, "sqlite3": true, "mssql": true}
+
+// preloadLimit return limit and offset set in preload conditions, e.g:
+//     db.Preload("Comments", func(db gorm.Repository) gorm.Repository { return db.Order("id DESC").Limit(3) })
+// for has_many associations, they are applied to every parent instead of the whole preload query
+func preloadLimit(db Repository) (limit, offset int64, ok bool) {
+	limit, offset = -1, -1
+	if search := db.Search(); search != nil {
+		if search.limit != nil {
+			if parsed, err := strconv.ParseInt(fmt.Sprint(search.limit), 0, 0); err == nil && parsed >= 0 {
+				limit = parsed
+			}
+		}
+		if search.offset != nil {
+			if parsed, err := strconv.ParseInt(fmt.Sprint(search.offset), 0, 0); err == nil && parsed > 0 {
+				offset = parsed
+			}
+		}
+	}
+	return limit, offset, limit >= 0 || offset > 0
+}
+
+// preloadWithLimit find has_many associations with limit and offset applied to every parent, uses `ROW_NUMBER()` if supported,
+// otherwise query associations of every parent one by one
+func (scope *Scope) preloadWithLimit(preloadDB Repository, field *Field, primaryKeys [][]interface{}, conditions []interface{}, results interface{}) error {
+	var (
+		relation      = field.Relationship
+		limit, offset int64
+	)
+	limit, offset, _ = preloadLimit(preloadDB)
+
+	foreignKeyCondition := func(primaryKeys [][]interface{}) (string, []interface{}) {
+		query := fmt.Sprintf("%v IN (%v)", toQueryCondition(scope, relation.ForeignDBNames), toQueryMarks(primaryKeys))
+		values := toQueryValues(primaryKeys)
+		if relation.PolymorphicType != "" {
+			query += fmt.Sprintf(" AND %v = ?", scope.Quote(relation.PolymorphicDBName))
+			values = append(values, relation.PolymorphicValue)
+		}
+		return query, values
+	}
+
+	useWindowFunction := scope.dialectSupports("window_function", supportWindowFunctions)
+	if value, ok := scope.Get("gorm:preload_window_function"); ok {
+		if enabled, ok := value.(bool); ok {
+			useWindowFunction = enabled
+		}
+	}
+
+	if !useWindowFunction {
+		resultsValue := indirect(reflect.ValueOf(results))
+		for _, primaryKey := range primaryKeys {
+			query, values := foreignKeyCondition([][]interface{}{primaryKey})
+			parentResults := makeSlice(field.Struct.Type)
+			if err := preloadDB.Where(query, values...).Limit(limit).Offset(offset).Find(parentResults, conditions...).Error(); err != nil {
+				return err
+			}
+			resultsValue.Set(reflect.AppendSlice(resultsValue, indirect(reflect.ValueOf(parentResults))))
+		}
+		return nil
+	}
+
+	query, values := foreignKeyCondition(primaryKeys)
+	innerScope := preloadDB.Where(query, values...).NewScope(results).inlineCondition(conditions...)
+	innerScope.InstanceSet("skip_bindvar", true)
+
+	var partitions []string
+	for _, foreignDBName := range relation.ForeignDBNames {
+		partitions = append(partitions, fmt.Sprintf("%v.%v", innerScope.QuotedTableName(), innerScope.Quote(foreignDBName)))
+	}
+
+	selectSQL := innerScope.selectSQL()
+	if selectSQL == "*" {
+		selectSQL = innerScope.QuotedTableName() + ".*"
+	}
+
+	orderSQL := strings.TrimPrefix(innerScope.orderSQL(), " ORDER BY ")
+	if orderSQL == "" {
+		if primaryField := innerScope.PrimaryField(); primaryField != nil {
+			orderSQL = fmt.Sprintf("%v.%v", innerScope.QuotedTableName(), innerScope.Quote(primaryField.DBName))
+		} else {
+			orderSQL = partitions[0]
+		}
+	}
+
+	innerScope.Search.ignoreOrderQuery = true
+	innerScope.Search.limit, innerScope.Search.offset = nil, nil
+	innerSQL := fmt.Sprintf("SELECT %v, ROW_NUMBER() OVER (PARTITION BY %v ORDER BY %v) AS gorm_row_number FROM %v %v",
+		selectSQL, strings.Join(partitions, ","), orderSQL, innerScope.QuotedTableName(), innerScope.CombinedConditionSql())
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	sql, vars := "SELECT * FROM (?) AS gorm_preload WHERE gorm_row_number > ?", []interface{}{Expr(innerSQL, innerScope.SQLVars...), offset}
+	if limit >= 0 {
+		sql += " AND gorm_row_number <= ?"
+		vars = append(vars, offset+limit)
+	}
+
+	return preloadDB.New().Unscoped().Raw(sql+" ORDER BY gorm_row_number", vars...).Find(results).Error()
+}
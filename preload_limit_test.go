@@ -0,0 +1,65 @@
+package gorm_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/zhinanxing/gorm/v3"
+)
+
+func TestPreloadWithLimitPerParent(t *testing.T) {
+	var names []string
+	for i := 0; i < 3; i++ {
+		user := User{Name: fmt.Sprintf("PreloadLimitUser%v", i)}
+		for j := 0; j < 4; j++ {
+			user.Emails = append(user.Emails, Email{Email: fmt.Sprintf("preload_limit_%v_%v@example.org", i, j)})
+		}
+		DB.Save(&user)
+		names = append(names, user.Name)
+	}
+
+	latest := func(db gorm.Repository) gorm.Repository {
+		return db.Order("id DESC").Limit(2)
+	}
+
+	// the per-parent fallback is always tested, the window function only if the server supports it
+	dbs := []gorm.Repository{DB, DB.Set("gorm:preload_window_function", false)}
+	if DialectSupports("window_function") {
+		dbs = append(dbs, DB.Set("gorm:preload_window_function", true))
+	}
+
+	for _, db := range dbs {
+		var users []User
+		if err := db.Preload("Emails", latest).Where("name IN (?)", names).Order("id").Find(&users).Error(); err != nil {
+			t.Fatalf("No error should happen when preload with limit, but got %v", err)
+		}
+
+		if len(users) != 3 {
+			t.Fatalf("Should find 3 users, but got %v", len(users))
+		}
+
+		for i, user := range users {
+			if len(user.Emails) != 2 {
+				t.Errorf("Should preload 2 emails for every user, but got %v", len(user.Emails))
+				continue
+			}
+
+			if user.Emails[0].Email != fmt.Sprintf("preload_limit_%v_3@example.org", i) || user.Emails[1].Email != fmt.Sprintf("preload_limit_%v_2@example.org", i) {
+				t.Errorf("Should preload latest emails in order, but got %v, %v", user.Emails[0].Email, user.Emails[1].Email)
+			}
+		}
+
+		users = nil
+		if err := db.Preload("Emails", func(db gorm.Repository) gorm.Repository {
+			return db.Order("id").Offset(3).Limit(10)
+		}).Where("name IN (?)", names).Find(&users).Error(); err != nil {
+			t.Fatalf("No error should happen when preload with offset, but got %v", err)
+		}
+
+		for i, user := range users {
+			if len(user.Emails) != 1 || user.Emails[0].Email != fmt.Sprintf("preload_limit_%v_3@example.org", i) {
+				t.Errorf("Should preload emails with offset for every user, but got %v", user.Emails)
+			}
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package gorm
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RedactedColumns column names whose values won't be printed in SQL logs and errors, e.g:
+//     gorm.RedactedColumns = []string{"password", "access_token"}
+// Fields could also be redacted with tag `gorm:"redact"`
+var RedactedColumns []string
+
+// redactedValue replaces values of redacted columns in logged SQL vars
+type redactedValue struct{}
+
+func (redactedValue) String() string {
+	return "***"
+}
+
+// redactedError error with redacted values removed from its message
+type redactedError struct {
+	err     error
+	message string
+}
+
+func (e redactedError) Error() string {
+	return e.message
+}
+
+func (e redactedError) Unwrap() error {
+	return e.err
+}
+
+var (
+	insertColumnsRegexp     = regexp.MustCompile("(?is)^\\s*INSERT\\s+INTO\\s+\\S+\\s*\\(([^)]*)\\)\\s*VALUES")
+	placeholderRegexp       = regexp.MustCompile("\\?|\\$\\d+")
+	placeholderColumnRegexp = regexp.MustCompile("(?i)([\\w\"`\\[\\]\\.]+)\\s*(=|<>|!=|<=|>=|<|>|\\sLIKE|\\sIN\\s*\\(\\s*)\\s*$")
+)
+
+func normalizeColumnName(column string) string {
+	column = strings.Trim(strings.TrimSpace(column), "\"`[]")
+	if idx := strings.LastIndex(column, "."); idx != -1 {
+		column = strings.Trim(column[idx+1:], "\"`[]")
+	}
+	return strings.ToLower(column)
+}
+
+// redactedColumns return redacted columns of current scope, from RedactedColumns and model's fields with tag `redact`
+func (scope *Scope) redactedColumns() map[string]bool {
+	columns := map[string]bool{}
+	for _, column := range RedactedColumns {
+		columns[normalizeColumnName(column)] = true
+	}
+
+	for _, field := range scope.GetModelStruct().StructFields {
+		if _, ok := field.TagSettings["REDACT"]; ok {
+			columns[strings.ToLower(field.DBName)] = true
+		}
+	}
+	return columns
+}
+
+// redactedVars return SQLVars with values of redacted columns replaced, the column of a var is detected from the SQL,
+// the column list for INSERT, or `column = ?`, `column LIKE ?`, `column IN (?,?)` for others
+func (scope *Scope) redactedVars() []interface{} {
+	if len(scope.SQLVars) == 0 {
+		return scope.SQLVars
+	}
+
+	columns := scope.redactedColumns()
+	if len(columns) == 0 {
+		return scope.SQLVars
+	}
+
+	var (
+		vars          []interface{}
+		insertColumns []string
+		valuesStart   = -1
+		lastColumn    string
+		lastEnd       int
+	)
+
+	if matches := insertColumnsRegexp.FindStringSubmatchIndex(scope.SQL); matches != nil {
+		insertColumns = strings.Split(scope.SQL[matches[2]:matches[3]], ",")
+		valuesStart = matches[1]
+	}
+
+	for idx, loc := range placeholderRegexp.FindAllStringIndex(scope.SQL, -1) {
+		var (
+			column   string
+			varIndex = idx
+			prefix   = scope.SQL[lastEnd:loc[0]]
+		)
+		lastEnd = loc[1]
+
+		if scope.SQL[loc[0]] == '$' {
+			if n, err := strconv.Atoi(scope.SQL[loc[0]+1 : loc[1]]); err == nil {
+				varIndex = n - 1
+			}
+		}
+
+		if valuesStart != -1 && loc[0] > valuesStart && idx < len(insertColumns) {
+			column = insertColumns[idx]
+		} else if matches := placeholderColumnRegexp.FindStringSubmatch(prefix); matches != nil {
+			column = matches[1]
+		} else if strings.TrimSpace(prefix) == "," {
+			column = lastColumn
+		}
+		lastColumn = column
+
+		if varIndex >= 0 && varIndex < len(scope.SQLVars) && columns[normalizeColumnName(column)] {
+			if vars == nil {
+				vars = append([]interface{}{}, scope.SQLVars...)
+			}
+			vars[varIndex] = redactedValue{}
+		}
+	}
+
+	if vars == nil {
+		return scope.SQLVars
+	}
+	return vars
+}
+
+// minRedactedLength values shorter than it are too short to be found reliably in error messages, they are kept
+const minRedactedLength = 4
+
+// sentinelErrors gorm's errors compared with `==`, they never contain values, so won't be redacted
+var sentinelErrors = []error{ErrRecordNotFound, ErrInvalidSQL, ErrInvalidTransaction, ErrCantStartTransaction, ErrUnaddressable, ErrInvalidCursor}
+
+// redactError remove values of redacted columns from error's message, e.g: drivers' duplicated key errors,
+// values are only replaced where they appear as a whole token, quoted or delimited as drivers print them
+func (scope *Scope) redactError(err error) error {
+	if len(scope.SQL) == 0 || len(scope.SQLVars) == 0 {
+		return err
+	}
+
+	for _, sentinel := range sentinelErrors {
+		if err == sentinel {
+			return err
+		}
+	}
+
+	message := err.Error()
+	for idx, value := range scope.redactedVars() {
+		if _, ok := value.(redactedValue); ok {
+			if str := toString(scope.SQLVars[idx]); len(str) >= minRedactedLength && strings.Contains(message, str) {
+				tokenRegexp := regexp.MustCompile("(^|[\\s'\"`(=,:])" + regexp.QuoteMeta(str) + "($|[\\s'\"`),;:.])")
+				message = tokenRegexp.ReplaceAllString(message, "${1}"+fmt.Sprint(value)+"${2}")
+			}
+		}
+	}
+
+	if message != err.Error() {
+		return redactedError{err: err, message: message}
+	}
+	return err
+}
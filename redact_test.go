@@ -0,0 +1,85 @@
+package gorm_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/zhinanxing/gorm/v3"
+)
+
+type RedactedAccount struct {
+	ID       uint
+	Login    string `gorm:"unique_index"`
+	Password string `gorm:"redact"`
+	Token    string
+}
+
+type formattedLogRecorder struct {
+	logs []string
+}
+
+func (recorder *formattedLogRecorder) Print(values ...interface{}) {
+	recorder.logs = append(recorder.logs, fmt.Sprint(gorm.LogFormatter(values...)...))
+}
+
+func (recorder *formattedLogRecorder) String() string {
+	return strings.Join(recorder.logs, "\n")
+}
+
+func TestRedactSensitiveValuesInLogs(t *testing.T) {
+	DB.DropTableIfExists(&RedactedAccount{})
+	DB.AutoMigrate(&RedactedAccount{})
+
+	gorm.RedactedColumns = []string{"token"}
+	defer func() { gorm.RedactedColumns = nil }()
+
+	recorder := &formattedLogRecorder{}
+	db := DB.New().LogMode(true).SetLogger(recorder)
+
+	account := RedactedAccount{Login: "redact_login", Password: "secret-password", Token: "secret-token"}
+	db.Create(&account)
+	db.Model(&account).Updates(map[string]interface{}{"password": "secret-password2", "token": "secret-token2"})
+	db.Where("login = ? AND password = ?", "redact_login", "secret-password2").First(&RedactedAccount{})
+	db.Where(&RedactedAccount{Token: "secret-token2"}).Find(&[]RedactedAccount{})
+	db.Where("token IN (?)", []string{"secret-token2", "secret-token3"}).Find(&[]RedactedAccount{})
+
+	logs := recorder.String()
+	if strings.Contains(logs, "secret-") {
+		t.Errorf("Should not print redacted values, but got %v", logs)
+	}
+
+	if !strings.Contains(logs, "'redact_login'") || !strings.Contains(logs, "'***'") {
+		t.Errorf("Should print non-redacted values and mask redacted values, but got %v", logs)
+	}
+
+	recorder.logs = nil
+	if err := db.Create(&RedactedAccount{Login: "redact_login", Token: "secret-token4"}).Error(); err == nil {
+		t.Errorf("Should get duplicated key error")
+	} else if strings.Contains(err.Error(), "secret-") || strings.Contains(recorder.String(), "secret-") {
+		t.Errorf("Should not print redacted values in errors, but got %v, %v", err, recorder.String())
+	}
+
+	driverErr := errors.New("Duplicate entry 'secret-token5' for key 'token'")
+	scope := DB.NewScope(&RedactedAccount{}).Raw("UPDATE redacted_accounts SET token = ? WHERE id = ?")
+	scope.SQLVars = []interface{}{"secret-token5", 1}
+	returned := scope.Err(driverErr)
+	if err := scope.DB().Error(); err == nil || err.Error() != "Duplicate entry '***' for key 'token'" || !errors.Is(err, driverErr) {
+		t.Errorf("Should remove redacted values from error message, but got %v", err)
+	}
+
+	if returned == nil || returned.Error() != "Duplicate entry '***' for key 'token'" {
+		t.Errorf("Should return the redacted error, but got %v", returned)
+	}
+
+	if db := DB.Where("password = ?", "o").First(&RedactedAccount{}); !db.RecordNotFound() || db.Error() != gorm.ErrRecordNotFound {
+		t.Errorf("Should not redact sentinel errors, but got %v", db.Error())
+	}
+
+	scope = DB.NewScope(&RedactedAccount{}).Raw("UPDATE redacted_accounts SET token = ? WHERE id = ?")
+	scope.SQLVars = []interface{}{"token5", 1}
+	if err := scope.Err(errors.New("Duplicate entry 'token55' for key 'token5'")); err.Error() != "Duplicate entry 'token55' for key '***'" {
+		t.Errorf("Should only redact values as whole tokens, but got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package gorm
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// scanMapTimeLayouts layouts used to parse date and time columns returned as text, e.g: MySQL without `parseTime=true`
+var scanMapTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02",
+	"15:04:05",
+}
+
+// isScanMapType check the type is a map could hold any column, e.g: `map[string]interface{}`
+func isScanMapType(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Map && typ.Key().Kind() == reflect.String && typ.Elem().Kind() == reflect.Interface
+}
+
+// scanMap scan current row into the map, column values are normalized with `normalizeScanMapValue`
+func (scope *Scope) scanMap(rows *sql.Rows, columns []string, columnTypes []*sql.ColumnType, dest reflect.Value) {
+	values := make([]interface{}, len(columns))
+	for index := range columns {
+		values[index] = new(interface{})
+	}
+
+	if scope.Err(rows.Scan(values...)) != nil {
+		return
+	}
+
+	if dest.IsNil() {
+		dest.Set(reflect.MakeMap(dest.Type()))
+	}
+
+	for index, column := range columns {
+		var databaseType string
+		if index < len(columnTypes) && columnTypes[index] != nil {
+			databaseType = strings.ToUpper(columnTypes[index].DatabaseTypeName())
+		}
+
+		value := normalizeScanMapValue(*(values[index].(*interface{})), databaseType)
+		if value == nil {
+			dest.SetMapIndex(reflect.ValueOf(column).Convert(dest.Type().Key()), reflect.Zero(dest.Type().Elem()))
+		} else {
+			dest.SetMapIndex(reflect.ValueOf(column).Convert(dest.Type().Key()), reflect.ValueOf(value))
+		}
+	}
+}
+
+// normalizeScanMapValue convert driver values, `[]byte` of non-binary columns becomes string, date and time columns become `time.Time`
+func normalizeScanMapValue(value interface{}, databaseType string) interface{} {
+	isBinary := strings.Contains(databaseType, "BLOB") || strings.Contains(databaseType, "BINARY") ||
+		strings.Contains(databaseType, "BYTEA") || strings.Contains(databaseType, "IMAGE")
+
+	if bytes, ok := value.([]byte); ok {
+		if isBinary {
+			return append([]byte{}, bytes...)
+		}
+		value = string(bytes)
+	}
+
+	if str, ok := value.(string); ok && (strings.Contains(databaseType, "DATE") || strings.Contains(databaseType, "TIME")) {
+		for _, layout := range scanMapTimeLayouts {
+			if t, err := time.Parse(layout, str); err == nil {
+				return t
+			}
+		}
+	}
+	return value
+}
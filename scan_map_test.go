@@ -0,0 +1,52 @@
+package gorm_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestScanIntoMaps(t *testing.T) {
+	birthday := time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC)
+	DB.Save(&User{Name: "ScanMapUser1", Age: 18, Birthday: &birthday})
+	DB.Save(&User{Name: "ScanMapUser2", Age: 20})
+
+	var results []map[string]interface{}
+	if err := DB.Table("users").Select("name, age, birthday").Where("name LIKE ?", "ScanMapUser%").Order("name").Find(&results).Error(); err != nil {
+		t.Fatalf("No error should happen when find into maps, but got %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Should find 2 rows, but got %v", len(results))
+	}
+
+	if name, ok := results[0]["name"].(string); !ok || name != "ScanMapUser1" {
+		t.Errorf("Text column should be scanned as string, but got %#v", results[0]["name"])
+	}
+
+	if value, ok := results[0]["birthday"].(time.Time); !ok || !value.Equal(birthday) {
+		t.Errorf("Time column should be scanned as time, but got %#v", results[0]["birthday"])
+	}
+
+	if results[1]["birthday"] != nil {
+		t.Errorf("Null column should be scanned as nil, but got %#v", results[1]["birthday"])
+	}
+
+	var result map[string]interface{}
+	if err := DB.Model(&User{}).Where("name = ?", "ScanMapUser2").Scan(&result).Error(); err != nil {
+		t.Fatalf("No error should happen when scan into map, but got %v", err)
+	}
+
+	if result["name"] != "ScanMapUser2" || fmt.Sprint(result["age"]) != "20" {
+		t.Errorf("Should scan row into map, but got %#v", result)
+	}
+
+	result = map[string]interface{}{}
+	if err := DB.Table("users").Where("name = ?", "ScanMapUser1").First(&result).Error(); err != nil || result["name"] != "ScanMapUser1" {
+		t.Errorf("Should find first row into map, but got %#v, %v", result, err)
+	}
+
+	if !DB.Table("users").Where("name = ?", "ScanMapUserNotFound").First(&result).RecordNotFound() {
+		t.Errorf("Should return record not found error when no row found")
+	}
+}
@@ -0,0 +1,405 @@
+package gorm
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SchemaDocument schema of models exported by `ExportSchema`
+type SchemaDocument struct {
+	Dialect string        `json:"dialect"`
+	Tables  []SchemaTable `json:"tables"`
+}
+
+// SchemaTable table definition of a model
+type SchemaTable struct {
+	Model         string               `json:"model"`
+	Table         string               `json:"table"`
+	PrimaryKeys   []string             `json:"primaryKeys"`
+	Columns       []SchemaColumn       `json:"columns"`
+	Indexes       []SchemaIndex        `json:"indexes"`
+	ForeignKeys   []SchemaForeignKey   `json:"foreignKeys"`
+	Relationships []SchemaRelationship `json:"relationships"`
+	// JSONSchema JSON Schema of the Go struct, only exported when `gorm:export_json_schema` is set
+	JSONSchema map[string]interface{} `json:"jsonSchema,omitempty"`
+}
+
+// SchemaColumn column definition, Type is resolved by `Dialect.DataTypeOf` without constraints
+type SchemaColumn struct {
+	Name          string `json:"name"`
+	Field         string `json:"field"`
+	GoType        string `json:"goType"`
+	Type          string `json:"type"`
+	Size          int    `json:"size,omitempty"`
+	PrimaryKey    bool   `json:"primaryKey"`
+	AutoIncrement bool   `json:"autoIncrement"`
+	Nullable      bool   `json:"nullable"`
+	Unique        bool   `json:"unique"`
+	Default       string `json:"default,omitempty"`
+	Comment       string `json:"comment,omitempty"`
+}
+
+// SchemaIndex index definition
+type SchemaIndex struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Unique  bool     `json:"unique"`
+}
+
+// SchemaForeignKey foreign key of the table, derived from relationships
+type SchemaForeignKey struct {
+	Columns           []string `json:"columns"`
+	ReferencedTable   string   `json:"referencedTable"`
+	ReferencedColumns []string `json:"referencedColumns"`
+}
+
+// SchemaRelationship association of the model, Kind could be `has_one`, `has_many`, `belongs_to` or `many_to_many`
+type SchemaRelationship struct {
+	Field                  string   `json:"field"`
+	Kind                   string   `json:"kind"`
+	Model                  string   `json:"model"`
+	Table                  string   `json:"table"`
+	ForeignKeys            []string `json:"foreignKeys"`
+	AssociationForeignKeys []string `json:"associationForeignKeys"`
+	JoinTable              string   `json:"joinTable,omitempty"`
+	PolymorphicType        string   `json:"polymorphicType,omitempty"`
+	PolymorphicValue       string   `json:"polymorphicValue,omitempty"`
+}
+
+// exportSchema build schema document of models, tables are ordered by table name
+func (scope *Scope) exportSchema(models ...interface{}) (*SchemaDocument, error) {
+	var (
+		document       = &SchemaDocument{Dialect: scope.Dialect().GetName(), Tables: []SchemaTable{}}
+		tableIndexes   = map[string]int{}
+		pendingForeign []struct {
+			table      string
+			foreignKey SchemaForeignKey
+		}
+	)
+
+	value, _ := scope.Get("gorm:export_json_schema")
+	jsonSchema, _ := value.(bool)
+
+	for _, model := range models {
+		modelScope := scope.New(model)
+		modelStruct := modelScope.GetModelStruct()
+		if modelStruct.ModelType == nil || modelStruct.ModelType.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("unsupported model %T, should be a struct", model)
+		}
+
+		tableName := modelScope.TableName()
+		if _, ok := tableIndexes[tableName]; ok {
+			continue
+		}
+
+		table := SchemaTable{
+			Model:         modelStruct.ModelType.Name(),
+			Table:         tableName,
+			PrimaryKeys:   []string{},
+			Columns:       []SchemaColumn{},
+			Indexes:       []SchemaIndex{},
+			ForeignKeys:   []SchemaForeignKey{},
+			Relationships: []SchemaRelationship{},
+		}
+
+		for _, field := range modelStruct.PrimaryFields {
+			table.PrimaryKeys = append(table.PrimaryKeys, field.DBName)
+		}
+
+		indexes := map[string]*SchemaIndex{}
+		for _, field := range modelStruct.StructFields {
+			if field.IsIgnored {
+				continue
+			}
+
+			if field.IsNormal {
+				table.Columns = append(table.Columns, modelScope.schemaColumn(field))
+				modelScope.collectSchemaIndexes(field, table.Table, indexes)
+			} else if relationship := field.Relationship; relationship != nil {
+				schemaRelationship, foreignKey := modelScope.schemaRelationship(field)
+				table.Relationships = append(table.Relationships, schemaRelationship)
+
+				switch relationship.Kind {
+				case "belongs_to":
+					table.ForeignKeys = append(table.ForeignKeys, foreignKey)
+				case "has_one", "has_many":
+					// foreign keys of has_one/has_many are belonged to the associated table
+					pendingForeign = append(pendingForeign, struct {
+						table      string
+						foreignKey SchemaForeignKey
+					}{schemaRelationship.Table, foreignKey})
+				}
+			}
+		}
+
+		for _, index := range indexes {
+			table.Indexes = append(table.Indexes, *index)
+		}
+		sort.Slice(table.Indexes, func(i, j int) bool { return table.Indexes[i].Name < table.Indexes[j].Name })
+
+		if jsonSchema {
+			table.JSONSchema = jsonSchemaOf(modelStruct.ModelType)
+		}
+
+		tableIndexes[table.Table] = len(document.Tables)
+		document.Tables = append(document.Tables, table)
+	}
+
+	for _, pending := range pendingForeign {
+		if idx, ok := tableIndexes[pending.table]; ok && !hasSchemaForeignKey(document.Tables[idx].ForeignKeys, pending.foreignKey) {
+			document.Tables[idx].ForeignKeys = append(document.Tables[idx].ForeignKeys, pending.foreignKey)
+		}
+	}
+
+	for idx := range document.Tables {
+		foreignKeys := document.Tables[idx].ForeignKeys
+		sort.SliceStable(foreignKeys, func(i, j int) bool {
+			return strings.Join(foreignKeys[i].Columns, ",") < strings.Join(foreignKeys[j].Columns, ",")
+		})
+	}
+	sort.SliceStable(document.Tables, func(i, j int) bool { return document.Tables[i].Table < document.Tables[j].Table })
+
+	return document, nil
+}
+
+func (scope *Scope) schemaColumn(field *StructField) SchemaColumn {
+	column := SchemaColumn{
+		Name:       field.DBName,
+		Field:      field.Name,
+		GoType:     field.Struct.Type.String(),
+		PrimaryKey: field.IsPrimaryKey,
+		Comment:    field.TagSettings["COMMENT"],
+	}
+
+	// dialects mark auto increment fields in tag settings when resolving their types
+	autoIncrementField := field.clone()
+	scope.Dialect().DataTypeOf(autoIncrementField)
+	_, column.AutoIncrement = autoIncrementField.TagSettings["AUTO_INCREMENT"]
+
+	// resolve the data type without constraints, which are exported separately, e.g: `integer primary key autoincrement`
+	// of sqlite or `bigint AUTO_INCREMENT` of mysql for primary keys are resolved as `integer` and `bigint`
+	typeField := field.clone()
+	for _, key := range []string{"NOT NULL", "UNIQUE", "DEFAULT", "COMMENT"} {
+		delete(typeField.TagSettings, key)
+	}
+	typeField.IsPrimaryKey = false
+	typeField.TagSettings["AUTO_INCREMENT"] = "false"
+	column.Type = strings.TrimSpace(scope.Dialect().DataTypeOf(typeField))
+
+	if size, ok := field.TagSettings["SIZE"]; ok {
+		column.Size, _ = strconv.Atoi(size)
+	}
+
+	_, notNull := field.TagSettings["NOT NULL"]
+	column.Nullable = !notNull && !field.IsPrimaryKey
+	_, column.Unique = field.TagSettings["UNIQUE"]
+
+	if value, ok := field.TagSettings["DEFAULT"]; ok {
+		column.Default = value
+	}
+	return column
+}
+
+// collectSchemaIndexes collect indexes of the field, named the same as `AutoMigrate`
+func (scope *Scope) collectSchemaIndexes(field *StructField, tableName string, indexes map[string]*SchemaIndex) {
+	for _, setting := range []struct {
+		key    string
+		prefix string
+		unique bool
+	}{{"INDEX", "idx", false}, {"UNIQUE_INDEX", "uix", true}} {
+		value, ok := field.TagSettings[setting.key]
+		if !ok {
+			continue
+		}
+
+		for _, name := range strings.Split(value, ",") {
+			if name == setting.key || name == "" {
+				name = scope.Dialect().BuildKeyName(setting.prefix, tableName, field.DBName)
+			}
+
+			if index, ok := indexes[name]; ok {
+				index.Columns = append(index.Columns, field.DBName)
+			} else {
+				indexes[name] = &SchemaIndex{Name: name, Columns: []string{field.DBName}, Unique: setting.unique}
+			}
+		}
+	}
+}
+
+// schemaRelationship build the relationship of the field, and the foreign key it implies
+func (scope *Scope) schemaRelationship(field *StructField) (SchemaRelationship, SchemaForeignKey) {
+	var (
+		relationship = field.Relationship
+		fieldType    = field.Struct.Type
+	)
+
+	for fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	associationScope := scope.New(reflect.New(fieldType).Interface())
+	result := SchemaRelationship{
+		Field:                  field.Name,
+		Kind:                   relationship.Kind,
+		Model:                  fieldType.Name(),
+		Table:                  associationScope.TableName(),
+		ForeignKeys:            append([]string{}, relationship.ForeignDBNames...),
+		AssociationForeignKeys: append([]string{}, relationship.AssociationForeignDBNames...),
+		PolymorphicType:        relationship.PolymorphicDBName,
+		PolymorphicValue:       relationship.PolymorphicValue,
+	}
+
+	if relationship.Kind == "many_to_many" && relationship.JoinTableHandler != nil {
+		result.JoinTable = relationship.JoinTableHandler.Table(scope.db)
+	}
+
+	foreignKey := SchemaForeignKey{
+		Columns:           result.ForeignKeys,
+		ReferencedTable:   result.Table,
+		ReferencedColumns: result.AssociationForeignKeys,
+	}
+	if relationship.Kind == "has_one" || relationship.Kind == "has_many" {
+		foreignKey.ReferencedTable = scope.TableName()
+	}
+	return result, foreignKey
+}
+
+func hasSchemaForeignKey(foreignKeys []SchemaForeignKey, foreignKey SchemaForeignKey) bool {
+	for _, key := range foreignKeys {
+		if key.ReferencedTable == foreignKey.ReferencedTable && reflect.DeepEqual(key.Columns, foreignKey.Columns) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonSchemaOf build JSON Schema (draft-07) for the shape of the Go struct when encoded by `encoding/json`,
+// nested structs are put into `definitions`
+func jsonSchemaOf(modelType reflect.Type) map[string]interface{} {
+	definitions := map[string]interface{}{}
+	schema := jsonSchemaOfStruct(modelType, definitions)
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["title"] = modelType.Name()
+	if len(definitions) > 0 {
+		schema["definitions"] = definitions
+	}
+	return schema
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+func jsonSchemaOfStruct(structType reflect.Type, definitions map[string]interface{}) map[string]interface{} {
+	var (
+		properties = map[string]interface{}{}
+		required   = []string{}
+	)
+
+	var collect func(reflect.Type)
+	collect = func(structType reflect.Type) {
+		for i := 0; i < structType.NumField(); i++ {
+			field := structType.Field(i)
+			tag := field.Tag.Get("json")
+			if tag == "-" || (field.PkgPath != "" && !field.Anonymous) {
+				continue
+			}
+
+			name, options := tag, ""
+			if idx := strings.Index(tag, ","); idx >= 0 {
+				name, options = tag[:idx], tag[idx:]
+			}
+
+			fieldType := field.Type
+			if field.Anonymous && name == "" {
+				if fieldType.Kind() == reflect.Ptr {
+					fieldType = fieldType.Elem()
+				}
+				if fieldType.Kind() == reflect.Struct {
+					collect(fieldType)
+					continue
+				}
+			}
+
+			if name == "" {
+				name = field.Name
+			}
+
+			property := jsonSchemaOfType(field.Type, definitions)
+			if strings.Contains(options, ",string") {
+				property = map[string]interface{}{"type": "string"}
+			}
+			properties[name] = property
+
+			if field.Type.Kind() != reflect.Ptr && !strings.Contains(options, ",omitempty") {
+				required = append(required, name)
+			}
+		}
+	}
+	collect(structType)
+
+	sort.Strings(required)
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func jsonSchemaOfType(typ reflect.Type, definitions map[string]interface{}) map[string]interface{} {
+	if typ.Kind() == reflect.Ptr {
+		schema := jsonSchemaOfType(typ.Elem(), definitions)
+		if _, isRef := schema["$ref"]; isRef {
+			return map[string]interface{}{"oneOf": []interface{}{schema, map[string]interface{}{"type": "null"}}}
+		}
+		if value, ok := schema["type"].(string); ok {
+			schema["type"] = []string{value, "null"}
+		}
+		return schema
+	}
+
+	switch {
+	case typ == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case typ.Implements(jsonMarshalerType) || reflect.PtrTo(typ).Implements(jsonMarshalerType):
+		// encoded by its own MarshalJSON, the shape is unknown
+		return map[string]interface{}{}
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 && typ.Kind() == reflect.Slice {
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]interface{}{"type": "array", "items": jsonSchemaOfType(typ.Elem(), definitions)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": jsonSchemaOfType(typ.Elem(), definitions)}
+	case reflect.Struct:
+		name := typ.Name()
+		if name == "" {
+			return jsonSchemaOfStruct(typ, definitions)
+		}
+		if _, ok := definitions[name]; !ok {
+			// placeholder to stop recursion of self referenced structs
+			definitions[name] = map[string]interface{}{}
+			definitions[name] = jsonSchemaOfStruct(typ, definitions)
+		}
+		return map[string]interface{}{"$ref": "#/definitions/" + name}
+	}
+	return map[string]interface{}{}
+}
@@ -0,0 +1,191 @@
+package gorm_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zhinanxing/gorm/v3"
+)
+
+type SchemaExportAuthor struct {
+	gorm.Model
+	Name  string             `gorm:"size:100;not null;unique_index:uix_author_name"`
+	Email string             `gorm:"index"`
+	Posts []SchemaExportPost `gorm:"foreignkey:AuthorID"`
+}
+
+type SchemaExportPost struct {
+	ID          int64
+	Title       string `json:"title" gorm:"default:'untitled'"`
+	AuthorID    uint   `gorm:"index:idx_post_author_published"`
+	Author      *SchemaExportAuthor
+	PublishedAt *time.Time        `json:"publishedAt,omitempty" gorm:"index:idx_post_author_published"`
+	Tags        []SchemaExportTag `gorm:"many2many:schema_export_post_tags"`
+}
+
+type SchemaExportTag struct {
+	ID   int64
+	Name string
+}
+
+func exportSchema(t *testing.T, db gorm.Repository, models ...interface{}) gorm.SchemaDocument {
+	data, err := db.ExportSchema(models...)
+	if err != nil {
+		t.Fatalf("Should export schema, but got %v", err)
+	}
+
+	var document gorm.SchemaDocument
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("Should export valid JSON, but got %v", err)
+	}
+	return document
+}
+
+func findSchemaTable(document gorm.SchemaDocument, name string) *gorm.SchemaTable {
+	for idx := range document.Tables {
+		if document.Tables[idx].Table == name {
+			return &document.Tables[idx]
+		}
+	}
+	return nil
+}
+
+func TestExportSchema(t *testing.T) {
+	document := exportSchema(t, DB, &SchemaExportPost{}, &SchemaExportAuthor{}, &SchemaExportTag{}, &SchemaExportPost{})
+
+	if document.Dialect != DB.Dialect().GetName() {
+		t.Errorf("Dialect should be %v, but got %v", DB.Dialect().GetName(), document.Dialect)
+	}
+
+	var tables []string
+	for _, table := range document.Tables {
+		tables = append(tables, table.Table)
+	}
+	if len(tables) != 3 || tables[0] != "schema_export_authors" || tables[1] != "schema_export_posts" || tables[2] != "schema_export_tags" {
+		t.Fatalf("Tables should be ordered by name without duplication, but got %v", tables)
+	}
+
+	authors := findSchemaTable(document, "schema_export_authors")
+	if len(authors.PrimaryKeys) != 1 || authors.PrimaryKeys[0] != "id" {
+		t.Errorf("Primary keys of authors should be [id], but got %v", authors.PrimaryKeys)
+	}
+
+	for _, column := range authors.Columns {
+		if column.Name == "name" {
+			if column.Size != 100 || column.Nullable || column.Field != "Name" || column.GoType != "string" {
+				t.Errorf("Column name should be a not null string with size 100, but got %+v", column)
+			}
+			if column.Type == "" || strings.Contains(strings.ToUpper(column.Type), "NOT NULL") {
+				t.Errorf("Column type should be resolved by dialect without constraints, but got %v", column.Type)
+			}
+		}
+		if column.Name == "extra" || column.Name == "posts" {
+			t.Errorf("Ignored fields and associations shouldn't be exported as columns, but got %v", column.Name)
+		}
+	}
+
+	if len(authors.Indexes) != 3 || authors.Indexes[0].Name != "idx_schema_export_authors_deleted_at" ||
+		authors.Indexes[1].Name != "idx_schema_export_authors_email" || authors.Indexes[2].Name != "uix_author_name" || !authors.Indexes[2].Unique {
+		t.Errorf("Indexes of authors should be exported, but got %+v", authors.Indexes)
+	}
+
+	if len(authors.Relationships) != 1 || authors.Relationships[0].Kind != "has_many" || authors.Relationships[0].Table != "schema_export_posts" {
+		t.Errorf("Relationships of authors should be exported, but got %+v", authors.Relationships)
+	}
+
+	posts := findSchemaTable(document, "schema_export_posts")
+	for _, column := range posts.Columns {
+		if column.Name == "title" && column.Default != "'untitled'" {
+			t.Errorf("Default value of title should be exported, but got %+v", column)
+		}
+		if column.Name == "id" && (!column.PrimaryKey || column.Nullable) {
+			t.Errorf("Column id should be the primary key, but got %+v", column)
+		}
+		if column.Name == "id" {
+			columnType := strings.ToLower(column.Type)
+			if !column.AutoIncrement || strings.Contains(columnType, "primary") || strings.Contains(columnType, "auto") ||
+				strings.Contains(columnType, "identity") || strings.Contains(columnType, "serial") {
+				t.Errorf("Type of id should be resolved without primary key and auto increment, but got %+v", column)
+			}
+		}
+	}
+
+	if len(posts.Indexes) != 1 || len(posts.Indexes[0].Columns) != 2 || posts.Indexes[0].Columns[0] != "author_id" || posts.Indexes[0].Columns[1] != "published_at" {
+		t.Errorf("Composite index should be exported, but got %+v", posts.Indexes)
+	}
+
+	if len(posts.ForeignKeys) != 1 || posts.ForeignKeys[0].Columns[0] != "author_id" || posts.ForeignKeys[0].ReferencedTable != "schema_export_authors" || posts.ForeignKeys[0].ReferencedColumns[0] != "id" {
+		t.Errorf("Foreign key of posts should be exported once, but got %+v", posts.ForeignKeys)
+	}
+
+	kinds := map[string]gorm.SchemaRelationship{}
+	for _, relationship := range posts.Relationships {
+		kinds[relationship.Field] = relationship
+	}
+	if kinds["Author"].Kind != "belongs_to" || kinds["Tags"].Kind != "many_to_many" || kinds["Tags"].JoinTable != "schema_export_post_tags" {
+		t.Errorf("Relationships of posts should be exported, but got %+v", posts.Relationships)
+	}
+
+	if posts.JSONSchema != nil {
+		t.Errorf("JSON Schema shouldn't be exported by default")
+	}
+
+	first, _ := DB.ExportSchema(&SchemaExportAuthor{}, &SchemaExportPost{}, &SchemaExportTag{})
+	second, _ := DB.ExportSchema(&SchemaExportTag{}, &SchemaExportPost{}, &SchemaExportAuthor{})
+	if !bytes.Equal(first, second) {
+		t.Errorf("Exported schema should be stable")
+	}
+
+	if _, err := DB.ExportSchema(1); err == nil {
+		t.Errorf("Should return error for unsupported model")
+	}
+}
+
+func TestExportSchemaWithJSONSchema(t *testing.T) {
+	document := exportSchema(t, DB.Set("gorm:export_json_schema", true), &SchemaExportPost{})
+	schema := document.Tables[0].JSONSchema
+	if schema == nil {
+		t.Fatalf("JSON Schema should be exported")
+	}
+
+	if schema["$schema"] != "http://json-schema.org/draft-07/schema#" || schema["type"] != "object" || schema["title"] != "SchemaExportPost" {
+		t.Errorf("JSON Schema should describe the struct, but got %v", schema)
+	}
+
+	properties := schema["properties"].(map[string]interface{})
+	if title := properties["title"].(map[string]interface{}); title["type"] != "string" {
+		t.Errorf("Property should be named by json tag, but got %v", title)
+	}
+
+	publishedAt := properties["publishedAt"].(map[string]interface{})
+	if types, ok := publishedAt["type"].([]interface{}); !ok || len(types) != 2 || types[0] != "string" || types[1] != "null" || publishedAt["format"] != "date-time" {
+		t.Errorf("Pointer of time should be a nullable date-time, but got %v", publishedAt)
+	}
+
+	if author := properties["Author"].(map[string]interface{}); author["oneOf"] == nil {
+		t.Errorf("Pointer of struct should reference its definition, but got %v", author)
+	}
+
+	if tags := properties["Tags"].(map[string]interface{}); tags["type"] != "array" {
+		t.Errorf("Slice should be an array, but got %v", tags)
+	}
+
+	definitions := schema["definitions"].(map[string]interface{})
+	author := definitions["SchemaExportAuthor"].(map[string]interface{})
+	authorProperties := author["properties"].(map[string]interface{})
+	if _, ok := authorProperties["createdAt"]; !ok {
+		t.Errorf("Embedded struct should be flattened, but got %v", authorProperties)
+	}
+	if _, ok := authorProperties["Posts"]; !ok {
+		t.Errorf("Self referenced structs should be exported, but got %v", authorProperties)
+	}
+
+	for _, name := range schema["required"].([]interface{}) {
+		if name == "publishedAt" || name == "Author" {
+			t.Errorf("Pointer fields shouldn't be required, but got %v", name)
+		}
+	}
+}
@@ -79,9 +79,10 @@ func (scope *Scope) Quote(str string) string {
 	return scope.Dialect().Quote(str)
 }
 
-// Err add error to Scope
+// Err add error to Scope, values of redacted columns are removed from its message, the redacted error is returned
 func (scope *Scope) Err(err error) error {
 	if err != nil {
+		err = scope.redactError(err)
 		scope.db.AddError(err)
 	}
 	return err
@@ -361,6 +362,7 @@ func (scope *Scope) Exec() *Scope {
 	defer scope.trace(NowFunc())
 
 	if !scope.HasError() {
+		scope.addComment()
 		if result, err := scope.SQLDB().Exec(scope.SQL, scope.SQLVars...); scope.Err(err) == nil {
 			if count, err := result.RowsAffected(); scope.Err(err) == nil {
 				scope.db.SetRowsAffected(count)
@@ -402,9 +404,13 @@ func (scope *Scope) InstanceGet(name string) (interface{}, bool) {
 // Begin start a transaction
 func (scope *Scope) Begin() *Scope {
 	if db, ok := scope.SQLDB().(sqlDb); ok {
-		if tx, err := db.Begin(); err == nil {
+		t := NowFunc()
+		tx, err := db.Begin()
+		recordTransactionMetric(scope.db, "begin", t, err)
+		if err == nil {
 			scope.db.SetSQLCommonDB(interface{}(tx).(SQLCommon))
 			scope.InstanceSet("gorm:started_transaction", true)
+			startTransactionSpan(scope.db)
 		}
 	}
 	return scope
@@ -414,10 +420,16 @@ func (scope *Scope) Begin() *Scope {
 func (scope *Scope) CommitOrRollback() *Scope {
 	if _, ok := scope.InstanceGet("gorm:started_transaction"); ok {
 		if db, ok := scope.db.SQLCommonDB().(sqlTx); ok {
+			t := NowFunc()
 			if scope.HasError() {
-				db.Rollback()
+				err := db.Rollback()
+				recordTransactionMetric(scope.db, "rollback", t, err)
+				endTransactionSpan(scope.db, "rollback", err)
 			} else {
-				scope.Err(db.Commit())
+				err := db.Commit()
+				recordTransactionMetric(scope.db, "commit", t, err)
+				endTransactionSpan(scope.db, "commit", err)
+				scope.Err(err)
 			}
 			scope.db.SetSQLCommonDB(scope.db.Parent().SQLCommonDB())
 		}
@@ -480,6 +492,9 @@ func (scope *Scope) scan(rows *sql.Rows, columns []string, fields []*Field) {
 		selectFields       []*Field
 		selectedColumnsMap = map[string]int{}
 		resetFields        = map[int]*Field{}
+		joinedValues       = map[string]*joinedValue{}
+		joinedFields       = map[int]*Field{}
+		joinedOwners       = map[int]*joinedValue{}
 	)
 
 	for index, column := range columns {
@@ -508,6 +523,13 @@ func (scope *Scope) scan(rows *sql.Rows, columns []string, fields []*Field) {
 				}
 			}
 		}
+
+		// columns of associations loaded with `JoinsPreload`, e.g: `Company__name`
+		if values[index] == &ignored {
+			if value, field, joined := scope.joinedColumnValue(column, fields, joinedValues); value != nil {
+				values[index], joinedFields[index], joinedOwners[index] = value, field, joined
+			}
+		}
 	}
 
 	scope.Err(rows.Scan(values...))
@@ -517,12 +539,39 @@ func (scope *Scope) scan(rows *sql.Rows, columns []string, fields []*Field) {
 			field.Field.Set(v)
 		}
 	}
+
+	for index, field := range joinedFields {
+		if v := reflect.ValueOf(values[index]).Elem(); !v.IsNil() {
+			field.Field.Set(v.Elem())
+			joinedOwners[index].loaded = true
+		}
+	}
+
+	for _, joined := range joinedValues {
+		if joined != nil {
+			joined.assign()
+		}
+	}
 }
 
 func (scope *Scope) primaryCondition(value interface{}) string {
 	return fmt.Sprintf("(%v.%v = %v)", scope.QuotedTableName(), scope.Quote(scope.PrimaryKey()), value)
 }
 
+// clauseArgs return args of the clause, malformed args are added as an error instead of panicking
+func (scope *Scope) clauseArgs(clause map[string]interface{}) ([]interface{}, bool) {
+	value, ok := clause["args"]
+	if !ok || value == nil {
+		return nil, true
+	}
+
+	args, ok := value.([]interface{})
+	if !ok {
+		scope.Err(fmt.Errorf("invalid query arguments: %v", value))
+	}
+	return args, ok
+}
+
 func (scope *Scope) buildCondition(clause map[string]interface{}, include bool) (str string) {
 	var (
 		quotedTableName  = scope.QuotedTableName()
@@ -567,7 +616,18 @@ func (scope *Scope) buildCondition(clause map[string]interface{}, include bool)
 	case map[string]interface{}:
 		var sqls []string
 		for key, value := range value {
-			if value != nil {
+			// keys could carry operators, e.g: `age >`, `name LIKE`, `id IN`
+			if fields := strings.Fields(key); len(fields) > 1 {
+				sql, err := scope.operatorConditionSQL(fields[0], strings.Join(fields[1:], " "), value)
+				if scope.Err(err) != nil {
+					return ""
+				}
+
+				if !include {
+					sql = fmt.Sprintf("(NOT %v)", sql)
+				}
+				sqls = append(sqls, sql)
+			} else if value != nil {
 				sqls = append(sqls, fmt.Sprintf("(%v.%v %s %v)", quotedTableName, scope.Quote(key), equalSQL, scope.AddToVars(value)))
 			} else {
 				if !include {
@@ -578,6 +638,16 @@ func (scope *Scope) buildCondition(clause map[string]interface{}, include bool)
 			}
 		}
 		return strings.Join(sqls, " AND ")
+	case *Condition:
+		sql, err := scope.conditionSQL(value)
+		if scope.Err(err) != nil {
+			return ""
+		}
+
+		if !include {
+			return fmt.Sprintf("(NOT %v)", sql)
+		}
+		return sql
 	case interface{}:
 		var sqls []string
 		newScope := scope.New(value)
@@ -587,8 +657,29 @@ func (scope *Scope) buildCondition(clause map[string]interface{}, include bool)
 			return
 		}
 
+		// fields named in args are used even if blank, e.g: `db.Where(&User{Active: false}, "Active")`
+		names, ok := scope.clauseArgs(clause)
+		if !ok {
+			return
+		}
+
+		namedFields, err := namedStructFields(value, names)
+		if scope.Err(err) != nil {
+			return
+		}
+
 		for _, field := range newScope.Fields() {
-			if !field.IsIgnored && !field.IsBlank {
+			if _, named := namedFields[field.DBName]; field.IsIgnored || (field.IsBlank && !named) {
+				continue
+			}
+
+			if field.Field.Kind() == reflect.Ptr && field.Field.IsNil() {
+				if !include {
+					sqls = append(sqls, fmt.Sprintf("(%v.%v IS NOT NULL)", quotedTableName, scope.Quote(field.DBName)))
+				} else {
+					sqls = append(sqls, fmt.Sprintf("(%v.%v IS NULL)", quotedTableName, scope.Quote(field.DBName)))
+				}
+			} else {
 				sqls = append(sqls, fmt.Sprintf("(%v.%v %s %v)", quotedTableName, scope.Quote(field.DBName), equalSQL, scope.AddToVars(field.Field.Interface())))
 			}
 		}
@@ -598,8 +689,12 @@ func (scope *Scope) buildCondition(clause map[string]interface{}, include bool)
 		return
 	}
 
+	args, ok := scope.clauseArgs(clause)
+	if !ok {
+		return ""
+	}
+
 	replacements := []string{}
-	args := clause["args"].([]interface{})
 	for _, arg := range args {
 		var err error
 		switch reflect.ValueOf(arg).Kind() {
@@ -671,7 +766,11 @@ func (scope *Scope) buildSelectQuery(clause map[string]interface{}) (str string)
 		str = strings.Join(value, ", ")
 	}
 
-	args := clause["args"].([]interface{})
+	args, ok := scope.clauseArgs(clause)
+	if !ok {
+		return ""
+	}
+
 	replacements := []string{}
 	for _, arg := range args {
 		switch reflect.ValueOf(arg).Kind() {
@@ -765,13 +864,20 @@ func (scope *Scope) whereSQL() (sql string) {
 }
 
 func (scope *Scope) selectSQL() string {
+	var joinsPreloadSelect string
+	if value, ok := scope.InstanceGet("gorm:joins_preload"); ok {
+		if fields, ok := value.([]joinsPreloadField); ok {
+			joinsPreloadSelect = scope.joinsPreloadSelectSQL(fields)
+		}
+	}
+
 	if len(scope.Search.selects) == 0 {
-		if len(scope.Search.joinConditions) > 0 {
-			return fmt.Sprintf("%v.*", scope.QuotedTableName())
+		if len(scope.Search.joinConditions) > 0 || len(scope.Search.joinsPreload) > 0 {
+			return fmt.Sprintf("%v.*", scope.QuotedTableName()) + joinsPreloadSelect
 		}
 		return "*"
 	}
-	return scope.buildSelectQuery(scope.Search.selects)
+	return scope.buildSelectQuery(scope.Search.selects) + joinsPreloadSelect
 }
 
 func (scope *Scope) orderSQL() string {
@@ -833,14 +939,20 @@ func (scope *Scope) joinsSQL() string {
 		}
 	}
 
+	if len(scope.Search.joinsPreload) > 0 {
+		joinConditions = append(joinConditions, scope.joinsPreloadSQL())
+	}
+
 	return strings.Join(joinConditions, " ") + " "
 }
 
 func (scope *Scope) prepareQuerySQL() {
 	if scope.Search.raw {
 		scope.Raw(scope.CombinedConditionSql())
+	} else if len(scope.Search.setOperations) > 0 {
+		scope.Raw(scope.withSQL() + scope.setOperationSQL())
 	} else {
-		scope.Raw(fmt.Sprintf("SELECT %v FROM %v %v", scope.selectSQL(), scope.QuotedTableName(), scope.CombinedConditionSql()))
+		scope.Raw(scope.withSQL() + fmt.Sprintf("SELECT %v FROM %v %v", scope.selectSQL(), scope.fromSQL(), scope.CombinedConditionSql()))
 	}
 	return
 }
@@ -853,6 +965,10 @@ func (scope *Scope) inlineCondition(values ...interface{}) *Scope {
 }
 
 func (scope *Scope) callCallbacks(funcs []*func(s *Scope)) *Scope {
+	if tracer := spanTracer(scope.db); tracer != nil {
+		defer scope.startSpan(tracer, scope.db.Parent().Callbacks().kindOf(funcs))()
+	}
+
 	for _, f := range funcs {
 		(*f)(scope)
 		if scope.skipLeft {
@@ -974,7 +1090,9 @@ func (scope *Scope) pluck(column string, value interface{}) *Scope {
 		return scope
 	}
 
-	if query, ok := scope.Search.selects["query"]; !ok || !scope.isQueryForColumn(query, column) {
+	if len(scope.Search.setOperations) > 0 {
+		scope.InstanceSet("gorm:set_operation_select", column)
+	} else if query, ok := scope.Search.selects["query"]; !ok || !scope.isQueryForColumn(query, column) {
 		scope.Search.Select(column)
 	}
 
@@ -995,19 +1113,42 @@ func (scope *Scope) pluck(column string, value interface{}) *Scope {
 }
 
 func (scope *Scope) count(value interface{}) *Scope {
+	scope.Search.ignoreOrderQuery = true
 	if query, ok := scope.Search.selects["query"]; !ok || !countingQueryRegexp.MatchString(fmt.Sprint(query)) {
+		if len(scope.Search.setOperations) != 0 {
+			return scope.countSubQuery(value)
+		}
+
 		if len(scope.Search.group) != 0 {
-			scope.Search.Select("count(*) FROM ( SELECT count(*) as name ")
-			scope.Search.group += " ) AS count_table"
-		} else {
-			scope.Search.Select("count(*)")
+			// the select is kept in the sub query, as having conditions could reference its aliases
+			if !ok {
+				scope.Search.Select("1")
+			}
+			return scope.countSubQuery(value)
 		}
+		scope.Search.Select("count(*)")
 	}
-	scope.Search.ignoreOrderQuery = true
 	scope.Err(scope.row().Scan(value))
 	return scope
 }
 
+// countSubQuery count rows of current query by wrapping it as a sub query, used for grouped queries,
+// so `HAVING` conditions are applied before counting
+func (scope *Scope) countSubQuery(value interface{}) *Scope {
+	scope.InstanceSet("skip_bindvar", true)
+
+	// common table expressions are hoisted outside of the sub query, postgres and mssql don't accept `FROM (WITH ...)`
+	withSQL, ctes := scope.withSQL(), scope.Search.ctes
+	vars := scope.SQLVars
+	scope.SQLVars, scope.Search.ctes = nil, nil
+	scope.prepareQuerySQL()
+	scope.Search.ctes = ctes
+
+	vars = append(vars, Expr(scope.SQL, scope.SQLVars...))
+	scope.Err(scope.db.New().Raw(withSQL+"SELECT count(*) FROM (?) AS count_table", vars...).Row().Scan(value))
+	return scope
+}
+
 func (scope *Scope) typeName() string {
 	typ := scope.IndirectValue().Type()
 
@@ -1018,10 +1159,11 @@ func (scope *Scope) typeName() string {
 	return typ.Name()
 }
 
-// trace print sql log
+// trace print sql log, and report it to metrics recorder
 func (scope *Scope) trace(t time.Time) {
 	if len(scope.SQL) > 0 {
-		scope.db.Slog(scope.SQL, t, scope.SQLVars...)
+		scope.db.Slog(scope.SQL, t, scope.redactedVars()...)
+		scope.recordMetric(t)
 	}
 }
 
@@ -17,10 +17,15 @@ type Search struct {
 	omits            []string
 	orders           []interface{}
 	preload          []searchPreload
+	joinsPreload     []string
+	preloadCount     []searchPreload
+	ctes             []searchCTE
+	setOperations    []searchSetOperation
 	offset           interface{}
 	limit            interface{}
 	group            string
 	tableName        string
+	fromQuery        interface{}
 	raw              bool
 	Unscoped         bool
 	ignoreOrderQuery bool
@@ -31,6 +36,17 @@ type searchPreload struct {
 	conditions []interface{}
 }
 
+type searchSetOperation struct {
+	operator string
+	query    Repository
+}
+
+type searchCTE struct {
+	name      string
+	query     interface{}
+	recursive bool
+}
+
 func (s *Search) clone() *Search {
 	clone := *s
 	return &clone
@@ -123,6 +139,50 @@ func (s *Search) Preload(schema string, values ...interface{}) *Search {
 	return s
 }
 
+func (s *Search) PreloadCount(schema string, values ...interface{}) *Search {
+	var preloads []searchPreload
+	for _, preload := range s.preloadCount {
+		if preload.schema != schema {
+			preloads = append(preloads, preload)
+		}
+	}
+	preloads = append(preloads, searchPreload{schema, values})
+	s.preloadCount = preloads
+	return s
+}
+
+func (s *Search) JoinsPreload(schema string) *Search {
+	for _, preload := range s.joinsPreload {
+		if preload == schema {
+			return s
+		}
+	}
+	// copy before appending, the slice is shared with the search it is cloned from
+	s.joinsPreload = append(append([]string{}, s.joinsPreload...), schema)
+	return s
+}
+
+func (s *Search) With(name string, query interface{}, recursive bool) *Search {
+	var ctes []searchCTE
+	for _, cte := range s.ctes {
+		if cte.name != name {
+			ctes = append(ctes, cte)
+		}
+	}
+	s.ctes = append(ctes, searchCTE{name: name, query: query, recursive: recursive})
+	return s
+}
+
+func (s *Search) SetOperation(operator string, queries ...Repository) *Search {
+	var operations []searchSetOperation
+	operations = append(operations, s.setOperations...)
+	for _, query := range queries {
+		operations = append(operations, searchSetOperation{operator: operator, query: query})
+	}
+	s.setOperations = operations
+	return s
+}
+
 func (s *Search) Raw(b bool) *Search {
 	s.raw = b
 	return s
@@ -135,6 +195,13 @@ func (s *Search) unscoped() *Search {
 
 func (s *Search) Table(name string) *Search {
 	s.tableName = name
+	s.fromQuery = nil
+	return s
+}
+
+func (s *Search) From(query interface{}, alias string) *Search {
+	s.tableName = alias
+	s.fromQuery = query
 	return s
 }
 
@@ -0,0 +1,24 @@
+package gorm
+
+import (
+	"fmt"
+)
+
+// setOperationSQL combine current query with queries added by `Union`, `UnionAll`, `Intersect` and `Except`,
+// the combined result is selected as a derived table named with current table, so order, limit and offset apply to it
+func (scope *Scope) setOperationSQL() string {
+	sql := fmt.Sprintf("SELECT %v FROM %v %v%v%v%v", scope.selectSQL(), scope.fromSQL(),
+		scope.joinsSQL(), scope.whereSQL(), scope.groupSQL(), scope.havingSQL())
+
+	for _, operation := range scope.Search.setOperations {
+		sql += fmt.Sprintf(" %v %v", operation.operator, scope.AddToVars(operation.query.QueryExpr()))
+	}
+
+	// `Pluck` selects the column from the combined result
+	outerSelect := "*"
+	if column, ok := scope.InstanceGet("gorm:set_operation_select"); ok {
+		outerSelect = scope.quoteIfPossible(fmt.Sprint(column))
+	}
+
+	return fmt.Sprintf("SELECT %v FROM (%v) AS %v%v%v", outerSelect, sql, scope.QuotedTableName(), scope.orderSQL(), scope.limitAndOffsetSQL())
+}
@@ -0,0 +1,46 @@
+package gorm_test
+
+import (
+	"testing"
+)
+
+func TestSetOperations(t *testing.T) {
+	for i := 0; i < 6; i++ {
+		DB.Save(&User{Name: "SetOperationUser", Age: int64(i * 10)})
+	}
+
+	scopedDB := DB.Model(&User{}).Select("name, age").Where("name = ?", "SetOperationUser")
+	young, old := scopedDB.Where("age < ?", 20), scopedDB.Where("age > ?", 30)
+
+	var users []User
+	if err := scopedDB.Where("age < ?", 20).Union(old).Order("age DESC").Limit(3).Find(&users).Error(); err != nil {
+		t.Fatalf("No error should happen when query with union, but got %v", err)
+	}
+
+	if len(users) != 3 || users[0].Age != 50 || users[1].Age != 40 || users[2].Age != 10 {
+		t.Errorf("Order and limit should apply to combined result, but got %#v", users)
+	}
+
+	var count int
+	scopedDB.Where("age < ?", 20).UnionAll(young, old).Count(&count)
+	if count != 6 {
+		t.Errorf("Should count combined rows, but got %v", count)
+	}
+
+	// MySQL before 8.0.31 and MariaDB before 10.3 don't support INTERSECT and EXCEPT
+	if !DialectSupports("intersect_except") {
+		return
+	}
+
+	var ages []int64
+	scopedDB.Where("age > ?", 10).Intersect(scopedDB.Where("age < ?", 40)).Order("age").Pluck("age", &ages)
+	if len(ages) != 2 || ages[0] != 20 || ages[1] != 30 {
+		t.Errorf("Should intersect queries, but got %v", ages)
+	}
+
+	ages = nil
+	scopedDB.Except(young, old).Order("age").Pluck("age", &ages)
+	if len(ages) != 2 || ages[0] != 20 || ages[1] != 30 {
+		t.Errorf("Should except queries, but got %v", ages)
+	}
+}
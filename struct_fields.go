@@ -0,0 +1,44 @@
+package gorm
+
+import (
+	"fmt"
+)
+
+// namedStructFields find fields of the struct by name or db name, they are used in conditions and updates even if blank, e.g:
+//     db.Where(&User{Active: false}, "Active").Find(&users)
+func namedStructFields(value interface{}, names []interface{}) (map[string]*Field, error) {
+	var (
+		fields  = (&Scope{Value: value}).Fields()
+		results = map[string]*Field{}
+	)
+
+	for _, name := range names {
+		found := false
+		for _, field := range fields {
+			if field.IsNormal && (field.Name == name || field.DBName == name) {
+				results[field.DBName] = field
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("can't find field %v in %T", name, value)
+		}
+	}
+	return results, nil
+}
+
+// updateAttrsWithFields convert the struct to update attributes, fields named are included even if blank
+func updateAttrsWithFields(value interface{}, names []interface{}, withIgnoredField bool) (map[string]interface{}, error) {
+	fields, err := namedStructFields(value, names)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := convertInterfaceToMap(value, withIgnoredField)
+	for dbName, field := range fields {
+		attrs[dbName] = field.Field.Interface()
+	}
+	return attrs, nil
+}
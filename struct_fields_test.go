@@ -0,0 +1,63 @@
+package gorm_test
+
+import (
+	"testing"
+)
+
+func TestStructConditionsWithNamedFields(t *testing.T) {
+	DB.Save(&User{Name: "NamedFieldsUser", Age: 0}).Save(&User{Name: "NamedFieldsUser", Age: 18})
+
+	var users []User
+	DB.Where(&User{Name: "NamedFieldsUser", Age: 0}).Find(&users)
+	if len(users) != 2 {
+		t.Errorf("Blank fields of struct conditions should be skipped, but got %v", len(users))
+	}
+
+	DB.Where(&User{Name: "NamedFieldsUser", Age: 0}, "Age").Find(&users)
+	if len(users) != 1 || users[0].Age != 0 {
+		t.Errorf("Named blank fields should be used in conditions, but got %#v", users)
+	}
+
+	DB.Where(&User{Name: "NamedFieldsUser"}, "birthday").Not(&User{Age: 18}, "age").Find(&users)
+	if len(users) != 1 || users[0].Age != 0 {
+		t.Errorf("Named nil fields should be NULL conditions, but got %#v", users)
+	}
+
+	if err := DB.Where(&User{Name: "NamedFieldsUser"}, "Unknown").Find(&users).Error(); err == nil {
+		t.Errorf("Should return error for unknown field")
+	}
+}
+
+func TestUpdatesFields(t *testing.T) {
+	user := User{Name: "UpdatesFieldsUser", Age: 18, Email: "updates_fields@example.org"}
+	DB.Save(&user)
+
+	DB.Model(&user).Updates(User{Name: "UpdatesFieldsUserNew", Age: 0})
+	var result User
+	DB.First(&result, user.Id)
+	if result.Name != "UpdatesFieldsUserNew" || result.Age != 18 {
+		t.Errorf("Blank fields should be skipped by Updates, but got %#v", result)
+	}
+
+	if err := DB.Model(&user).UpdatesFields(User{Name: "UpdatesFieldsUser", Age: 0}, "Age").Error(); err != nil {
+		t.Fatalf("No error should happen when updates fields, but got %v", err)
+	}
+
+	DB.First(&result, user.Id)
+	if result.Name != "UpdatesFieldsUser" || result.Age != 0 || result.Email != "updates_fields@example.org" {
+		t.Errorf("Named blank fields should be updated, but got %#v", result)
+	}
+
+	if err := DB.Table("users").Where("id = ?", user.Id).UpdatesFields(User{Age: 0}, "Email").Error(); err != nil {
+		t.Fatalf("No error should happen when updates fields of table, but got %v", err)
+	}
+
+	DB.First(&result, user.Id)
+	if result.Email != "" {
+		t.Errorf("Named blank fields should be updated with table, but got %#v", result.Email)
+	}
+
+	if err := DB.Model(&user).UpdatesFields(User{Name: "UpdatesFieldsUser"}, "Unknown").Error(); err == nil {
+		t.Errorf("Should return error for unknown field")
+	}
+}
@@ -0,0 +1,128 @@
+package gorm
+
+import (
+	"context"
+)
+
+// Tracer starts spans around every callback chain (create, query, update, delete, row_query) and every transaction,
+// enable it with:
+//     db = db.Set("gorm:tracer", tracer)
+// Parent span is taken from the context set with `WithContext`. The shape follows OpenTelemetry's Tracer API,
+// so it could be adapted with:
+//     type otelTracer struct{ trace.Tracer }
+//
+//     func (t otelTracer) Start(ctx context.Context, name string) (context.Context, gorm.Span) {
+//       ctx, span := t.Tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+//       return ctx, otelSpan{span}
+//     }
+//
+//     type otelSpan struct{ trace.Span }
+//
+//     func (s otelSpan) SetAttribute(key string, value interface{}) {
+//       s.Span.SetAttributes(attribute.String(key, fmt.Sprint(value)))
+//     }
+//
+//     func (s otelSpan) RecordError(err error) {
+//       s.Span.RecordError(err)
+//       s.Span.SetStatus(codes.Error, err.Error())
+//     }
+//
+//     func (s otelSpan) End() { s.Span.End() }
+type Tracer interface {
+	Start(ctx context.Context, spanName string) (context.Context, Span)
+}
+
+// Span a started span, returned by Tracer
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+func contextOf(db Repository) context.Context {
+	if ctx, ok := db.Get("gorm:context"); ok {
+		if ctx, ok := ctx.(context.Context); ok && ctx != nil {
+			return ctx
+		}
+	}
+	return context.Background()
+}
+
+func spanTracer(db Repository) Tracer {
+	if db != nil {
+		if tracer, ok := db.Get("gorm:tracer"); ok {
+			if tracer, ok := tracer.(Tracer); ok {
+				return tracer
+			}
+		}
+	}
+	return nil
+}
+
+// kindOf return the kind of the callback chain, e.g: create, query
+func (c *Callback) kindOf(funcs []*func(scope *Scope)) string {
+	if len(funcs) > 0 {
+		for _, callbacks := range []struct {
+			kind  string
+			funcs []*func(scope *Scope)
+		}{
+			{"create", c.creates}, {"update", c.updates}, {"delete", c.deletes}, {"query", c.queries}, {"row_query", c.rowQueries},
+		} {
+			if len(callbacks.funcs) > 0 && &callbacks.funcs[0] == &funcs[0] {
+				return callbacks.kind
+			}
+		}
+	}
+	return "callbacks"
+}
+
+// startSpan start a span for current callback chain, nested operations will use it as parent, call returned func to end it
+func (scope *Scope) startSpan(tracer Tracer, kind string) func() {
+	parentCtx, hasParentCtx := scope.Get("gorm:context")
+	ctx, span := tracer.Start(scope.Context(), "gorm:"+kind)
+	scope.Set("gorm:context", ctx)
+
+	return func() {
+		span.SetAttribute("db.system", scope.Dialect().GetName())
+		span.SetAttribute("db.operation", kind)
+		if len(scope.SQL) > 0 {
+			span.SetAttribute("db.statement", scope.SQL)
+		}
+		if scope.Value != nil && !scope.Search.raw {
+			span.SetAttribute("db.sql.table", scope.TableName())
+		}
+		span.SetAttribute("db.rows_affected", scope.db.RowsAffected())
+		if err := scope.db.Error(); err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+
+		if hasParentCtx {
+			scope.Set("gorm:context", parentCtx)
+		} else {
+			delete(scope.db.Values(), "gorm:context")
+		}
+	}
+}
+
+// startTransactionSpan start a span for the transaction, it is ended by endTransactionSpan when committing or rolling back
+func startTransactionSpan(db Repository) {
+	if tracer := spanTracer(db); tracer != nil {
+		ctx, span := tracer.Start(contextOf(db), "gorm:transaction")
+		span.SetAttribute("db.system", db.Dialect().GetName())
+		db.InstantSet("gorm:context", ctx).InstantSet("gorm:transaction_span", span)
+	}
+}
+
+func endTransactionSpan(db Repository, result string, err error) {
+	if span, ok := db.Get("gorm:transaction_span"); ok {
+		if span, ok := span.(Span); ok && span != nil {
+			span.SetAttribute("db.transaction", result)
+			if err != nil {
+				span.RecordError(err)
+			}
+			span.End()
+			db.InstantSet("gorm:transaction_span", nil)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package gorm_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zhinanxing/gorm/v3"
+)
+
+type recordedSpan struct {
+	name       string
+	parent     *recordedSpan
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (span *recordedSpan) SetAttribute(key string, value interface{}) {
+	span.attributes[key] = value
+}
+
+func (span *recordedSpan) RecordError(err error) {
+	span.err = err
+}
+
+func (span *recordedSpan) End() {
+	span.ended = true
+}
+
+type spanContextKey struct{}
+
+type memoryTracer struct {
+	spans []*recordedSpan
+}
+
+func (tracer *memoryTracer) Start(ctx context.Context, name string) (context.Context, gorm.Span) {
+	span := &recordedSpan{name: name, attributes: map[string]interface{}{}}
+	span.parent, _ = ctx.Value(spanContextKey{}).(*recordedSpan)
+	tracer.spans = append(tracer.spans, span)
+	return context.WithValue(ctx, spanContextKey{}, span), span
+}
+
+func TestTracerSpans(t *testing.T) {
+	tracer := &memoryTracer{}
+	root := &recordedSpan{name: "request", attributes: map[string]interface{}{}}
+	db := DB.Set("gorm:tracer", tracer).WithContext(context.WithValue(context.Background(), spanContextKey{}, root))
+
+	user := User{Name: "TracerUser", Emails: []Email{{Email: "tracer@example.org"}}}
+	db.Save(&user)
+
+	if len(tracer.spans) < 3 {
+		t.Fatalf("Should record spans for create with associations, but got %v", len(tracer.spans))
+	}
+
+	create, transaction := tracer.spans[0], tracer.spans[1]
+	if create.name != "gorm:create" || create.parent != root || !create.ended {
+		t.Errorf("Should start create span under the span from context, but got %#v", create)
+	}
+
+	if create.attributes["db.sql.table"] != "users" || create.attributes["db.rows_affected"] != int64(1) || create.attributes["db.statement"] == nil {
+		t.Errorf("Should set attributes for create span, but got %#v", create.attributes)
+	}
+
+	if transaction.name != "gorm:transaction" || transaction.parent != create || transaction.attributes["db.transaction"] != "commit" || !transaction.ended {
+		t.Errorf("Should record implicit transaction under create span, but got %#v", transaction)
+	}
+
+	for _, span := range tracer.spans[2:] {
+		if span.parent == root || !span.ended {
+			t.Errorf("Nested operations should be children of the create span, but got %#v", span)
+		}
+	}
+
+	tracer.spans = nil
+	db.Where("name = ?", "no such user").First(&User{})
+	if len(tracer.spans) != 1 || tracer.spans[0].name != "gorm:query" || tracer.spans[0].parent != root || tracer.spans[0].err == nil {
+		t.Errorf("Should record query span with error, but got %#v", tracer.spans)
+	}
+
+	tracer.spans = nil
+	tx := db.Begin()
+	tx.Model(&user).Update("name", "TracerUser2")
+	tx.Rollback()
+	if len(tracer.spans) < 2 || tracer.spans[0].name != "gorm:transaction" || tracer.spans[1].parent != tracer.spans[0] {
+		t.Fatalf("Should record update span under transaction span, but got %#v", tracer.spans)
+	}
+
+	if span := tracer.spans[0]; !span.ended || span.attributes["db.transaction"] != "rollback" || span.parent != root {
+		t.Errorf("Should end transaction span when rolling back, but got %#v", span)
+	}
+}
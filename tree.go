@@ -0,0 +1,258 @@
+package gorm
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// supportRecursiveCTE dialects support recursive common table expressions, mysql depends on the server version
+// (MySQL 8.0, MariaDB 10.2), it could be enabled or disabled with:
+//     db.Set("gorm:recursive_cte", false)
+var supportRecursiveCTE = map[string]bool{"postgres": true, "sqlite3": true, "mssql": true}
+
+// treeRelation return the self-referencing has_many association of the model, e.g: `Children []Category` with `ParentID`
+func (scope *Scope) treeRelation() (*Relationship, error) {
+	modelStruct := scope.GetModelStruct()
+	for _, field := range modelStruct.StructFields {
+		if field.Relationship == nil || field.Relationship.Kind != "has_many" || len(field.Relationship.ForeignDBNames) != 1 {
+			continue
+		}
+
+		fieldType := field.Struct.Type
+		for fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if fieldType == modelStruct.ModelType {
+			return field.Relationship, nil
+		}
+	}
+	return nil, fmt.Errorf("can't find self-referencing has_many association for %s", modelStruct.ModelType)
+}
+
+// treeKeyValue return the value of the key, nil if it is blank
+func treeKeyValue(field *Field, ok bool) interface{} {
+	if !ok || !field.Field.IsValid() || field.IsBlank {
+		return nil
+	}
+
+	value := field.Field.Interface()
+	if valuer, ok := value.(driver.Valuer); ok {
+		value, _ = valuer.Value()
+	}
+	return value
+}
+
+// tree find descendants or ancestors of the node into scope's value, levels from the node could be loaded into
+// a field like `Depth int `gorm:"-"``, nodes are visited once, so cycles in the tree stop the traversal
+func (scope *Scope) tree(node interface{}, maxDepth int, ancestors bool) *Scope {
+	nodeScope := scope.New(node)
+	relation, err := nodeScope.treeRelation()
+	if err != nil {
+		scope.Err(err)
+		return scope
+	}
+
+	var (
+		foreignDBName = relation.ForeignDBNames[0]
+		primaryDBName = relation.AssociationForeignDBNames[0]
+		start         interface{}
+	)
+
+	if ancestors {
+		start = treeKeyValue(nodeScope.FieldByName(foreignDBName))
+	} else {
+		start = treeKeyValue(nodeScope.FieldByName(primaryDBName))
+	}
+
+	if start == nil {
+		results := indirect(reflect.ValueOf(scope.Value))
+		if results.Kind() == reflect.Slice {
+			results.Set(reflect.MakeSlice(results.Type(), 0, 0))
+		}
+		return scope
+	}
+
+	useRecursiveCTE := scope.dialectSupports("recursive_cte", supportRecursiveCTE)
+	if value, ok := scope.Get("gorm:recursive_cte"); ok {
+		if enabled, ok := value.(bool); ok {
+			useRecursiveCTE = enabled
+		}
+	}
+
+	if useRecursiveCTE {
+		return scope.treeWithRecursiveCTE(nodeScope, foreignDBName, primaryDBName, start, maxDepth, ancestors)
+	}
+	return scope.treeWithBreadthFirstQueries(nodeScope, foreignDBName, primaryDBName, start, maxDepth, ancestors)
+}
+
+// treePathSQL return SQL of the dialect for the visited path of the recursive CTE, which is keys joined like `,1,5,`:
+// the path of anchor rows, the path of recursive rows, and the condition that the key isn't visited yet
+func treePathSQL(dialect, key string) (anchorPath, recursivePath, notVisited string) {
+	switch dialect {
+	case "mysql":
+		key = fmt.Sprintf("CAST(%v AS CHAR)", key)
+		return fmt.Sprintf("CAST(CONCAT(',', %v, ',') AS CHAR(4000))", key),
+			fmt.Sprintf("CAST(CONCAT(gorm_tree.gorm_tree_path, %v, ',') AS CHAR(4000))", key),
+			fmt.Sprintf("INSTR(gorm_tree.gorm_tree_path, CONCAT(',', %v, ',')) = 0", key)
+	case "mssql":
+		key = fmt.Sprintf("CAST(%v AS NVARCHAR(MAX))", key)
+		return fmt.Sprintf("CAST(',' + %v + ',' AS NVARCHAR(MAX))", key),
+			fmt.Sprintf("CAST(gorm_tree.gorm_tree_path + %v + ',' AS NVARCHAR(MAX))", key),
+			fmt.Sprintf("CHARINDEX(',' + %v + ',', gorm_tree.gorm_tree_path) = 0", key)
+	}
+
+	position := "INSTR(gorm_tree.gorm_tree_path, ',' || %v || ',') = 0"
+	if dialect == "postgres" {
+		position = "STRPOS(gorm_tree.gorm_tree_path, ',' || %v || ',') = 0"
+	}
+	key = fmt.Sprintf("CAST(%v AS TEXT)", key)
+	return fmt.Sprintf("CAST(',' || %v || ',' AS TEXT)", key),
+		fmt.Sprintf("CAST(gorm_tree.gorm_tree_path || %v || ',' AS TEXT)", key),
+		fmt.Sprintf(position, key)
+}
+
+// treeWithRecursiveCTE find nodes with a recursive CTE, visited keys are tracked in the `gorm_tree_path` column like `,1,5,`,
+// so cycles in the tree stop the recursion
+func (scope *Scope) treeWithRecursiveCTE(nodeScope *Scope, foreignDBName, primaryDBName string, start interface{}, maxDepth int, ancestors bool) *Scope {
+	var (
+		dialect         = scope.Dialect().GetName()
+		tableName       = nodeScope.QuotedTableName()
+		anchorColumn    = primaryDBName
+		joinCondition   = fmt.Sprintf("%v.%v = gorm_tree.%v", tableName, scope.Quote(foreignDBName), scope.Quote(primaryDBName))
+		recursiveFilter string
+		deletedFilter   string
+		outerScope      = scope.db.NewScope(scope.Value)
+	)
+
+	anchorPath, recursivePath, notVisited := treePathSQL(dialect, fmt.Sprintf("%v.%v", tableName, scope.Quote(primaryDBName)))
+	recursiveFilter = " AND " + notVisited
+
+	if ancestors {
+		joinCondition = fmt.Sprintf("%v.%v = gorm_tree.%v", tableName, scope.Quote(primaryDBName), scope.Quote(foreignDBName))
+	} else {
+		anchorColumn = foreignDBName
+		if maxDepth > 0 {
+			recursiveFilter += fmt.Sprintf(" AND gorm_tree.gorm_tree_depth < %d", maxDepth)
+		}
+	}
+
+	if deletedAtField, ok := nodeScope.FieldByName("DeletedAt"); ok && !scope.Search.Unscoped {
+		deletedFilter = fmt.Sprintf(" AND %v.%v IS NULL", tableName, scope.Quote(deletedAtField.DBName))
+	}
+
+	with := "WITH RECURSIVE"
+	if dialect == "mssql" {
+		with = "WITH"
+	}
+
+	// the depth is selected as `depth`, unless the model has a column with that name
+	selectDepth := ", gorm_tree.gorm_tree_depth AS depth"
+	if field, ok := nodeScope.FieldByName("depth"); ok && !field.IsIgnored {
+		selectDepth = ""
+	}
+
+	// conditions, orders in the chain are applied to the outer query
+	outerScope.InstanceSet("skip_bindvar", true)
+	outerScope.Search.Table("gorm_tree")
+	if len(outerScope.Search.orders) == 0 {
+		outerScope.Search.Order("gorm_tree_depth")
+		outerScope.Search.Order(scope.Quote(primaryDBName))
+	}
+
+	sql := fmt.Sprintf("%v gorm_tree AS (SELECT %v.*, 1 AS gorm_tree_depth, %v AS gorm_tree_path FROM %v WHERE %v.%v = %v%v UNION ALL SELECT %v.*, gorm_tree.gorm_tree_depth + 1, %v FROM %v INNER JOIN gorm_tree ON %v WHERE 1 = 1%v%v) SELECT gorm_tree.*%v FROM gorm_tree %v",
+		with, tableName, anchorPath, tableName, tableName, scope.Quote(anchorColumn), outerScope.AddToVars(start), deletedFilter,
+		tableName, recursivePath, tableName, joinCondition, recursiveFilter, deletedFilter, selectDepth, outerScope.CombinedConditionSql())
+
+	// soft deleted nodes are filtered in the CTE with the caller's scoping, the raw query itself must be unscoped
+	scope.Err(scope.NewDB().Unscoped().Raw(sql, outerScope.SQLVars...).Find(scope.Value).Error())
+	return scope
+}
+
+// treeDB return a new DB to traverse the tree, soft deleted nodes are skipped unless the query is unscoped
+func (scope *Scope) treeDB() Repository {
+	if scope.Search.Unscoped {
+		return scope.NewDB().Unscoped()
+	}
+	return scope.NewDB()
+}
+
+func (scope *Scope) treeWithBreadthFirstQueries(nodeScope *Scope, foreignDBName, primaryDBName string, start interface{}, maxDepth int, ancestors bool) *Scope {
+	var (
+		depths   = map[string]int{}
+		keys     []interface{}
+		level    = []interface{}{start}
+		column   = foreignDBName
+		nextKeys = primaryDBName
+	)
+
+	if ancestors {
+		column, nextKeys = primaryDBName, foreignDBName
+	}
+
+	for depth := 1; len(level) > 0 && (maxDepth <= 0 || depth <= maxDepth); depth++ {
+		nodes := reflect.New(reflect.SliceOf(nodeScope.GetModelStruct().ModelType))
+		if err := scope.treeDB().Where(fmt.Sprintf("%v.%v IN (?)", nodeScope.QuotedTableName(), scope.Quote(column)), level).Find(nodes.Interface()).Error(); err != nil {
+			scope.Err(err)
+			return scope
+		}
+
+		level = nil
+		for i := 0; i < nodes.Elem().Len(); i++ {
+			node := scope.New(nodes.Elem().Index(i).Addr().Interface())
+			key := treeKeyValue(node.FieldByName(primaryDBName))
+			if _, visited := depths[toString(key)]; visited || key == nil {
+				continue
+			}
+
+			depths[toString(key)] = depth
+			keys = append(keys, key)
+			if next := treeKeyValue(node.FieldByName(nextKeys)); next != nil {
+				level = append(level, next)
+			}
+		}
+	}
+
+	results := indirect(reflect.ValueOf(scope.Value))
+	if len(keys) == 0 {
+		results.Set(reflect.MakeSlice(results.Type(), 0, 0))
+		return scope
+	}
+
+	// conditions, orders in the chain are applied to found nodes
+	db := scope.db.Where(fmt.Sprintf("%v.%v IN (?)", nodeScope.QuotedTableName(), scope.Quote(primaryDBName)), keys)
+	if len(scope.Search.orders) == 0 {
+		db = db.Order(fmt.Sprintf("%v.%v", nodeScope.QuotedTableName(), scope.Quote(primaryDBName)))
+	}
+
+	if err := db.Find(scope.Value).Error(); err != nil {
+		scope.Err(err)
+		return scope
+	}
+
+	elemScope := func(idx int) *Scope {
+		if elem := results.Index(idx); elem.Kind() == reflect.Ptr {
+			return scope.New(elem.Interface())
+		}
+		return scope.New(results.Index(idx).Addr().Interface())
+	}
+
+	depthOf := func(idx int) int {
+		return depths[toString(treeKeyValue(elemScope(idx).FieldByName(primaryDBName)))]
+	}
+
+	for i := 0; i < results.Len(); i++ {
+		if field, ok := elemScope(i).FieldByName("depth"); ok && field.IsIgnored {
+			field.Set(depthOf(i))
+		}
+	}
+
+	if len(scope.Search.orders) == 0 {
+		sort.SliceStable(results.Interface(), func(i, j int) bool {
+			return depthOf(i) < depthOf(j)
+		})
+	}
+	return scope
+}
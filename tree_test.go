@@ -0,0 +1,147 @@
+package gorm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zhinanxing/gorm/v3"
+)
+
+type TreeCategory struct {
+	Id        int64
+	Name      string
+	ParentID  *int64
+	Children  []TreeCategory `gorm:"foreignkey:ParentID"`
+	DeletedAt *time.Time
+	Depth     int `gorm:"-"`
+}
+
+func TestDescendantsAndAncestors(t *testing.T) {
+	DB.DropTableIfExists(&TreeCategory{})
+	if err := DB.AutoMigrate(&TreeCategory{}).Error(); err != nil {
+		t.Fatalf("Failed to migrate, got error: %v", err)
+	}
+
+	root := TreeCategory{Name: "root", Children: []TreeCategory{
+		{Name: "a", Children: []TreeCategory{
+			{Name: "a1", Children: []TreeCategory{{Name: "a1x"}}},
+			{Name: "a2"},
+		}},
+		{Name: "b", Children: []TreeCategory{{Name: "b1"}}},
+		{Name: "deleted", Children: []TreeCategory{{Name: "deleted1"}}},
+	}}
+	DB.Save(&root)
+	DB.Delete(&root.Children[2])
+
+	leaf := root.Children[0].Children[0].Children[0]
+
+	// breadth-first queries are always tested, the recursive CTE only if the server supports it
+	dbs := []gorm.Repository{DB, DB.Set("gorm:recursive_cte", false)}
+	if DialectSupports("recursive_cte") {
+		dbs = append(dbs, DB.Set("gorm:recursive_cte", true))
+	}
+
+	for _, db := range dbs {
+		var categories []TreeCategory
+		if err := db.Descendants(&root, &categories, 0).Error(); err != nil {
+			t.Fatalf("No error should happen when find descendants, but got %v", err)
+		}
+
+		if len(categories) != 6 {
+			t.Fatalf("Should find 6 descendants, but got %v", len(categories))
+		}
+
+		expects := map[string]int{"a": 1, "b": 1, "a1": 2, "a2": 2, "b1": 2, "a1x": 3}
+		for idx, category := range categories {
+			if expects[category.Name] != category.Depth {
+				t.Errorf("Depth of %v should be %v, but got %v", category.Name, expects[category.Name], category.Depth)
+			}
+
+			if idx > 0 && categories[idx-1].Depth > category.Depth {
+				t.Errorf("Descendants should be ordered by depth")
+			}
+		}
+
+		db.Descendants(&root, &categories, 2)
+		if len(categories) != 5 {
+			t.Errorf("Should find descendants up to max depth, but got %v", len(categories))
+		}
+
+		db.Where("name LIKE ?", "a%").Descendants(&root, &categories, 0)
+		if len(categories) != 4 {
+			t.Errorf("Should apply conditions to descendants, but got %v", len(categories))
+		}
+
+		db.Unscoped().Descendants(&root, &categories, 0)
+		if len(categories) != 8 {
+			t.Errorf("Should find soft deleted descendants when unscoped, but got %v", len(categories))
+		}
+
+		if err := db.Ancestors(&leaf, &categories).Error(); err != nil {
+			t.Fatalf("No error should happen when find ancestors, but got %v", err)
+		}
+
+		if len(categories) != 3 || categories[0].Name != "a1" || categories[1].Name != "a" || categories[2].Name != "root" || categories[2].Depth != 3 {
+			t.Errorf("Should find ancestors from parent to root, but got %#v", categories)
+		}
+
+		db.Ancestors(&root, &categories)
+		if len(categories) != 0 {
+			t.Errorf("Root should have no ancestors, but got %v", len(categories))
+		}
+	}
+
+	var emails []Email
+	if err := DB.Descendants(&Email{Id: 1}, &emails, 0).Error(); err == nil {
+		t.Errorf("Should return error for models without self-referencing association")
+	}
+}
+
+type TreeNode struct {
+	Id       int64
+	ParentID int64
+	Depth    int
+	Children []TreeNode `gorm:"foreignkey:ParentID"`
+}
+
+func TestTreeWithCycles(t *testing.T) {
+	DB.DropTableIfExists(&TreeNode{})
+	if err := DB.AutoMigrate(&TreeNode{}).Error(); err != nil {
+		t.Fatalf("Failed to migrate, got error: %v", err)
+	}
+
+	// 1 -> 2 -> 3 -> 1, the `depth` column is a real column of the model
+	for _, node := range []TreeNode{{Id: 1, ParentID: 3, Depth: 7}, {Id: 2, ParentID: 1, Depth: 7}, {Id: 3, ParentID: 2, Depth: 7}} {
+		DB.Create(&node)
+	}
+
+	dbs := []gorm.Repository{DB.Set("gorm:recursive_cte", false)}
+	if DialectSupports("recursive_cte") {
+		dbs = append(dbs, DB.Set("gorm:recursive_cte", true))
+	}
+
+	for _, db := range dbs {
+		var nodes []TreeNode
+		if err := db.Descendants(&TreeNode{Id: 1}, &nodes, 0).Error(); err != nil {
+			t.Fatalf("No error should happen when find descendants with cycles, but got %v", err)
+		}
+
+		if len(nodes) != 3 || nodes[0].Id != 2 || nodes[1].Id != 3 || nodes[2].Id != 1 {
+			t.Errorf("Should stop at visited nodes, but got %#v", nodes)
+		}
+
+		for _, node := range nodes {
+			if node.Depth != 7 {
+				t.Errorf("Should keep the depth column of the model, but got %v", node.Depth)
+			}
+		}
+
+		if err := db.Ancestors(&TreeNode{Id: 1, ParentID: 3}, &nodes).Error(); err != nil {
+			t.Fatalf("No error should happen when find ancestors with cycles, but got %v", err)
+		}
+
+		if len(nodes) != 3 || nodes[0].Id != 3 || nodes[1].Id != 2 || nodes[2].Id != 1 {
+			t.Errorf("Should stop at visited ancestors, but got %#v", nodes)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package gorm
+
+import (
+	"context"
+)
+
+// TypedRepository wraps `Repository` for model T, queries return values of T and errors, instead of scanning into `out interface{}`
+//     users := gorm.NewTypedRepository[User](db)
+//     user, err := users.Where("name = ?", "jinzhu").First(ctx)
+//     adults, err := users.Where("age >= ?", 18).Order("age").Find(ctx)
+type TypedRepository[T any] struct {
+	db Repository
+}
+
+// NewTypedRepository create a typed repository of model T
+func NewTypedRepository[T any](db Repository) TypedRepository[T] {
+	return TypedRepository[T]{db: db.Model(new(T))}
+}
+
+// DB return the underlying repository
+func (r TypedRepository[T]) DB() Repository {
+	return r.db
+}
+
+// with return the repository with the context for following operation
+func (r TypedRepository[T]) with(ctx context.Context) Repository {
+	if ctx == nil {
+		return r.db
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Where filter records with given conditions, refer `Repository.Where`
+func (r TypedRepository[T]) Where(query interface{}, args ...interface{}) TypedRepository[T] {
+	return TypedRepository[T]{db: r.db.Where(query, args...)}
+}
+
+// Or filter records that match before conditions or this one, refer `Repository.Or`
+func (r TypedRepository[T]) Or(query interface{}, args ...interface{}) TypedRepository[T] {
+	return TypedRepository[T]{db: r.db.Or(query, args...)}
+}
+
+// Not filter records that don't match the conditions, refer `Repository.Not`
+func (r TypedRepository[T]) Not(query interface{}, args ...interface{}) TypedRepository[T] {
+	return TypedRepository[T]{db: r.db.Not(query, args...)}
+}
+
+// Joins specify join conditions, refer `Repository.Joins`
+func (r TypedRepository[T]) Joins(query string, args ...interface{}) TypedRepository[T] {
+	return TypedRepository[T]{db: r.db.Joins(query, args...)}
+}
+
+// Select specify fields to retrieve, refer `Repository.Select`
+func (r TypedRepository[T]) Select(query interface{}, args ...interface{}) TypedRepository[T] {
+	return TypedRepository[T]{db: r.db.Select(query, args...)}
+}
+
+// Order specify order when retrieve records, refer `Repository.Order`
+func (r TypedRepository[T]) Order(value interface{}, reorder ...bool) TypedRepository[T] {
+	return TypedRepository[T]{db: r.db.Order(value, reorder...)}
+}
+
+// Limit specify the number of records to be retrieved
+func (r TypedRepository[T]) Limit(limit interface{}) TypedRepository[T] {
+	return TypedRepository[T]{db: r.db.Limit(limit)}
+}
+
+// Offset specify the number of records to skip before starting to return the records
+func (r TypedRepository[T]) Offset(offset interface{}) TypedRepository[T] {
+	return TypedRepository[T]{db: r.db.Offset(offset)}
+}
+
+// Preload preload associations with given conditions, refer `Repository.Preload`
+func (r TypedRepository[T]) Preload(column string, conditions ...interface{}) TypedRepository[T] {
+	return TypedRepository[T]{db: r.db.Preload(column, conditions...)}
+}
+
+// Unscoped include soft deleted records
+func (r TypedRepository[T]) Unscoped() TypedRepository[T] {
+	return TypedRepository[T]{db: r.db.Unscoped()}
+}
+
+// Scopes apply functions of `Repository` to current operation
+func (r TypedRepository[T]) Scopes(funcs ...func(Repository) Repository) TypedRepository[T] {
+	return TypedRepository[T]{db: r.db.Scopes(funcs...)}
+}
+
+// First find the first record ordered by primary key, `ErrRecordNotFound` is returned if not found
+func (r TypedRepository[T]) First(ctx context.Context, where ...interface{}) (T, error) {
+	var result T
+	err := r.with(ctx).First(&result, where...).Error()
+	return result, err
+}
+
+// Last find the last record ordered by primary key, `ErrRecordNotFound` is returned if not found
+func (r TypedRepository[T]) Last(ctx context.Context, where ...interface{}) (T, error) {
+	var result T
+	err := r.with(ctx).Last(&result, where...).Error()
+	return result, err
+}
+
+// Take find a record without specified order, `ErrRecordNotFound` is returned if not found
+func (r TypedRepository[T]) Take(ctx context.Context, where ...interface{}) (T, error) {
+	var result T
+	err := r.with(ctx).Take(&result, where...).Error()
+	return result, err
+}
+
+// Find find records that match given conditions
+func (r TypedRepository[T]) Find(ctx context.Context, where ...interface{}) ([]T, error) {
+	var results []T
+	err := r.with(ctx).Find(&results, where...).Error()
+	return results, err
+}
+
+// Count count records that match given conditions
+func (r TypedRepository[T]) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.with(ctx).Count(&count).Error()
+	return count, err
+}
+
+// Exists check if there are any records match given conditions
+func (r TypedRepository[T]) Exists(ctx context.Context) (bool, error) {
+	return r.with(ctx).Exists()
+}
+
+// Create insert the value into database
+func (r TypedRepository[T]) Create(ctx context.Context, value *T) error {
+	return r.with(ctx).Create(value).Error()
+}
+
+// Save update the value in database, insert it if its primary key is blank
+func (r TypedRepository[T]) Save(ctx context.Context, value *T) error {
+	return r.with(ctx).Save(value).Error()
+}
+
+// Update update attributes of records that match given conditions with callbacks, return the number of updated records
+//     users.Where("active = ?", false).Update(ctx, "name", "hello")
+func (r TypedRepository[T]) Update(ctx context.Context, attrs ...interface{}) (int64, error) {
+	db := r.with(ctx).Update(attrs...)
+	return db.RowsAffected(), db.Error()
+}
+
+// Updates update attributes of records that match given conditions with callbacks, values could be a map or T,
+// return the number of updated records
+func (r TypedRepository[T]) Updates(ctx context.Context, values interface{}) (int64, error) {
+	db := r.with(ctx).Updates(values)
+	return db.RowsAffected(), db.Error()
+}
+
+// Delete delete records that match given conditions, return the number of deleted records
+func (r TypedRepository[T]) Delete(ctx context.Context, where ...interface{}) (int64, error) {
+	db := r.with(ctx).Delete(new(T), where...)
+	return db.RowsAffected(), db.Error()
+}
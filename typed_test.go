@@ -0,0 +1,53 @@
+package gorm_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zhinanxing/gorm/v3"
+)
+
+func TestTypedRepository(t *testing.T) {
+	ctx := context.Background()
+	users := gorm.NewTypedRepository[User](DB)
+
+	user := User{Name: "TypedUser", Age: 18}
+	if err := users.Create(ctx, &user); err != nil || user.Id == 0 {
+		t.Fatalf("No error should happen when create, but got %v", err)
+	}
+	users.Create(ctx, &User{Name: "TypedUser", Age: 20})
+
+	found, err := users.Where("name = ?", "TypedUser").Order("age").First(ctx)
+	if err != nil || found.Id != user.Id {
+		t.Errorf("Should find first user, but got %#v, %v", found, err)
+	}
+
+	if _, err := users.First(ctx, "name = ?", "TypedUserNotFound"); err != gorm.ErrRecordNotFound {
+		t.Errorf("Should return record not found error, but got %v", err)
+	}
+
+	results, err := users.Where("name = ?", "TypedUser").Order("age desc").Find(ctx)
+	if err != nil || len(results) != 2 || results[0].Age != 20 {
+		t.Errorf("Should find users, but got %#v, %v", results, err)
+	}
+
+	if count, err := users.Where("name = ?", "TypedUser").Count(ctx); err != nil || count != 2 {
+		t.Errorf("Should count users, but got %v, %v", count, err)
+	}
+
+	if affected, err := users.Where("name = ? AND age = ?", "TypedUser", 20).Update(ctx, "age", 21); err != nil || affected != 1 {
+		t.Errorf("Should update users, but got %v, %v", affected, err)
+	}
+
+	if exists, err := users.Where("name = ? AND age = ?", "TypedUser", 21).Exists(ctx); err != nil || !exists {
+		t.Errorf("Should find updated user, but got %v, %v", exists, err)
+	}
+
+	if affected, err := users.Where("name = ?", "TypedUser").Delete(ctx); err != nil || affected != 2 {
+		t.Errorf("Should delete users, but got %v, %v", affected, err)
+	}
+
+	if _, err := users.Select("unknown_column").Find(ctx); err == nil {
+		t.Errorf("Should return error of invalid query")
+	}
+}